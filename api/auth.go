@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/lajosdeme/polymarket-go-api/client"
 	"github.com/lajosdeme/polymarket-go-api/types"
@@ -13,6 +15,8 @@ import (
 // AuthAPI handles authentication operations
 type AuthAPI struct {
 	client *client.ClobClient
+	nonces *NonceManager
+	store  CredentialStore
 }
 
 // NewAuthAPI creates a new AuthAPI instance
@@ -22,8 +26,52 @@ func NewAuthAPI(client *client.ClobClient) *AuthAPI {
 	}
 }
 
-// CreateAPIKey creates new API credentials for user
+// SetNonceManager sets the nonce manager recording the nonce of the account's API credentials.
+// BootstrapL2Auth and RotateAPIKey use its nonces instead of nonce 0.
+func (a *AuthAPI) SetNonceManager(nonces *NonceManager) {
+	a.nonces = nonces
+}
+
+// SetCredentialStore sets the store whose credentials DeleteAPIKey removes along with the key.
+// BootstrapL2Auth sets the store it is called with.
+func (a *AuthAPI) SetCredentialStore(store CredentialStore) {
+	a.store = store
+}
+
+// Nonce returns the nonce of the account's current API credentials, zero without a nonce manager
+func (a *AuthAPI) Nonce() uint64 {
+	if a.nonces == nil {
+		return 0
+	}
+	return a.nonces.Nonce(a.client.GetAuthManager().GetAddress())
+}
+
+// recordNonce records the nonce of the account's current API credentials
+func (a *AuthAPI) recordNonce(nonce uint64) error {
+	if a.nonces == nil {
+		return nil
+	}
+	if err := a.nonces.SetNonce(a.client.GetAuthManager().GetAddress(), nonce); err != nil {
+		return fmt.Errorf("failed to record nonce: %w", err)
+	}
+	return nil
+}
+
+// maxRotationAttempts bounds the nonces RotateAPIKey tries when they are already used
+const maxRotationAttempts = 10
+
+// CreateAPIKey creates new API credentials for user.
+// If credentials already exist for the nonce, they are derived instead.
 func (a *AuthAPI) CreateAPIKey(ctx context.Context, nonce uint64) (*types.APICredentials, error) {
+	credentials, err := a.createAPIKey(ctx, nonce)
+	if isNonceAlreadyUsed(err) {
+		return a.DeriveAPIKey(ctx, nonce)
+	}
+	return credentials, err
+}
+
+// createAPIKey creates new API credentials for nonce, failing if the nonce is already used
+func (a *AuthAPI) createAPIKey(ctx context.Context, nonce uint64) (*types.APICredentials, error) {
 	// Validate required L1 authentication
 	if !a.client.GetAuthManager().HasL1Auth() {
 		return nil, fmt.Errorf("L1 authentication required for creating API keys")
@@ -45,6 +93,10 @@ func (a *AuthAPI) CreateAPIKey(ctx context.Context, nonce uint64) (*types.APICre
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	if err := a.recordNonce(nonce); err != nil {
+		return nil, err
+	}
+
 	return &credentials, nil
 }
 
@@ -71,9 +123,246 @@ func (a *AuthAPI) DeriveAPIKey(ctx context.Context, nonce uint64) (*types.APICre
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	if err := a.recordNonce(nonce); err != nil {
+		return nil, err
+	}
+
 	return &credentials, nil
 }
 
+// RotateAPIKey creates new API credentials with the next nonce of the nonce manager and
+// installs them, e.g. to replace a compromised key before deleting it. It never returns
+// existing credentials: nonces that are already used are skipped.
+func (a *AuthAPI) RotateAPIKey(ctx context.Context) (*types.APICredentials, error) {
+	if a.nonces == nil {
+		return nil, fmt.Errorf("nonce manager required for rotating API keys")
+	}
+
+	// Validate required L1 authentication
+	authManager := a.client.GetAuthManager()
+	if !authManager.HasL1Auth() {
+		return nil, fmt.Errorf("L1 authentication required for creating API keys")
+	}
+
+	// The nonce is recorded once the credentials are created
+	nonce := a.nonces.Next(authManager.GetAddress())
+	var credentials *types.APICredentials
+	for attempt := 0; ; attempt++ {
+		var err error
+		credentials, err = a.createAPIKey(ctx, nonce)
+		if err == nil {
+			break
+		}
+		if !isNonceAlreadyUsed(err) || attempt+1 >= maxRotationAttempts {
+			return nil, fmt.Errorf("failed to create API key: %w", err)
+		}
+		nonce++
+	}
+
+	if err := a.client.RotateAPICredentials(*credentials); err != nil {
+		return nil, fmt.Errorf("failed to install API credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// isNonceAlreadyUsed reports whether err rejects an API key creation for a used nonce
+func isNonceAlreadyUsed(err error) bool {
+	clobErr, ok := types.AsClobError(err)
+	return ok && clobErr.Code == types.ErrNonceAlreadyUsed
+}
+
+// GetAPIKeys lists the API keys of the authenticated account
+func (a *AuthAPI) GetAPIKeys(ctx context.Context) (*types.APIKeysResponse, error) {
+	// Validate required L2 authentication
+	if !a.client.GetAuthManager().HasL2Auth() {
+		return nil, fmt.Errorf("L2 authentication required for listing API keys")
+	}
+
+	body, err := a.client.DoGet(ctx, "/auth/api-keys", true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.APIKeysResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DeleteAPIKey deletes the API key the client is authenticated with and removes its
+// credentials from the client and the credential store (see SetCredentialStore).
+// L2 authenticated requests fail afterwards until new credentials are installed.
+func (a *AuthAPI) DeleteAPIKey(ctx context.Context) error {
+	// Validate required L2 authentication
+	authManager := a.client.GetAuthManager()
+	if !authManager.HasL2Auth() {
+		return fmt.Errorf("L2 authentication required for deleting API keys")
+	}
+
+	if _, err := a.client.DoDelete(ctx, "/auth/api-key", nil); err != nil {
+		return err
+	}
+	a.client.ClearAPICredentials()
+
+	if a.store != nil {
+		if err := a.store.Delete(authManager.GetAddress()); err != nil {
+			return fmt.Errorf("failed to delete API credentials: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CreateReadonlyAPIKey creates a read-only API key for the authenticated account
+func (a *AuthAPI) CreateReadonlyAPIKey(ctx context.Context) (*types.ReadonlyAPIKeyResponse, error) {
+	// Validate required L2 authentication
+	if !a.client.GetAuthManager().HasL2Auth() {
+		return nil, fmt.Errorf("L2 authentication required for creating read-only API keys")
+	}
+
+	body, err := a.client.DoRequest(ctx, "POST", "/auth/readonly-api-key", nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.ReadonlyAPIKeyResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// GetReadonlyAPIKeys lists the read-only API keys of the authenticated account
+func (a *AuthAPI) GetReadonlyAPIKeys(ctx context.Context) ([]string, error) {
+	// Validate required L2 authentication
+	if !a.client.GetAuthManager().HasL2Auth() {
+		return nil, fmt.Errorf("L2 authentication required for listing read-only API keys")
+	}
+
+	body, err := a.client.DoGet(ctx, "/auth/readonly-api-keys", true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return keys, nil
+}
+
+// DeleteReadonlyAPIKey deletes a read-only API key of the authenticated account
+func (a *AuthAPI) DeleteReadonlyAPIKey(ctx context.Context, key string) error {
+	// Validate required L2 authentication
+	if !a.client.GetAuthManager().HasL2Auth() {
+		return fmt.Errorf("L2 authentication required for deleting read-only API keys")
+	}
+
+	request := types.DeleteReadonlyAPIKeyRequest{
+		Key: key,
+	}
+
+	_, err := a.client.DoDelete(ctx, "/auth/readonly-api-key", request)
+	return err
+}
+
+// CreateOrDeriveAPIKey derives the API credentials of nonce, creating them if none exist,
+// and installs them into the client's AuthManager for L2 authentication
+func (a *AuthAPI) CreateOrDeriveAPIKey(ctx context.Context, nonce uint64) (*types.APICredentials, error) {
+	credentials, err := a.DeriveAPIKey(ctx, nonce)
+	if err != nil {
+		// Deriving fails with a client error when no key exists for the nonce
+		clobErr, ok := types.AsClobError(err)
+		if !ok || clobErr.StatusCode < 400 || clobErr.StatusCode >= 500 || clobErr.IsAuthenticationError() {
+			return nil, fmt.Errorf("failed to derive API key: %w", err)
+		}
+
+		credentials, err = a.CreateAPIKey(ctx, nonce)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create API key: %w", err)
+		}
+	}
+
+	if err := a.client.RotateAPICredentials(*credentials); err != nil {
+		return nil, fmt.Errorf("failed to install API credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// BootstrapL2Auth sets up L2 authentication after L1 authentication, loading the credentials
+// of the signer address from store or, if none are stored, deriving or creating them with
+// the current nonce (see SetNonceManager) and saving them, so later starts skip the L1
+// round trip. Stored credentials the server rejects, e.g. of a deleted key, are removed
+// from store and replaced. store may be nil.
+func (a *AuthAPI) BootstrapL2Auth(ctx context.Context, store CredentialStore) (*types.APICredentials, error) {
+	authManager := a.client.GetAuthManager()
+	if !authManager.HasL1Auth() {
+		return nil, fmt.Errorf("L1 authentication required for setting up API credentials")
+	}
+	address := authManager.GetAddress()
+	a.store = store
+
+	if store != nil {
+		credentials, err := a.storedCredentials(ctx, store, address)
+		if err != nil {
+			return nil, err
+		}
+		if credentials != nil {
+			return credentials, nil
+		}
+	}
+
+	credentials, err := a.CreateOrDeriveAPIKey(ctx, a.Nonce())
+	if err != nil {
+		return nil, err
+	}
+
+	if store != nil {
+		if err := store.Save(address, *credentials); err != nil {
+			return nil, fmt.Errorf("failed to save API credentials: %w", err)
+		}
+	}
+
+	return credentials, nil
+}
+
+// storedCredentials installs the credentials of address stored in store once the server
+// accepts them, so rotation listeners never see rejected credentials. Rejected credentials
+// are deleted from store and nil is returned.
+func (a *AuthAPI) storedCredentials(ctx context.Context, store CredentialStore, address string) (*types.APICredentials, error) {
+	credentials, err := store.Load(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API credentials: %w", err)
+	}
+	if credentials == nil {
+		return nil, nil
+	}
+
+	// Probe with the candidate credentials instead of the installed ones
+	if _, err := a.client.DoGetWithCredentials(ctx, "/auth/api-keys", *credentials, nil); err != nil {
+		clobErr, ok := types.AsClobError(err)
+		if !ok || clobErr.StatusCode != http.StatusUnauthorized {
+			return nil, fmt.Errorf("failed to validate stored API credentials: %w", err)
+		}
+		if err := store.Delete(address); err != nil {
+			return nil, fmt.Errorf("failed to delete API credentials: %w", err)
+		}
+		return nil, nil
+	}
+
+	if err := a.client.RotateAPICredentials(*credentials); err != nil {
+		return nil, fmt.Errorf("failed to install API credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
 // GetServerTime gets the current server timestamp
 func (a *AuthAPI) GetServerTime(ctx context.Context) (int64, error) {
 	body, err := a.client.DoGet(ctx, "/time", false, nil)
@@ -90,3 +379,15 @@ func (a *AuthAPI) GetServerTime(ctx context.Context) (int64, error) {
 
 	return timestamp, nil
 }
+
+// StartClockSync syncs the client's clock with the server time and keeps it in sync every
+// interval until ctx is done, so L1/L2 timestamps and order expirations follow the server clock
+func (a *AuthAPI) StartClockSync(ctx context.Context, interval time.Duration) (*client.ServerClock, error) {
+	clock := client.NewServerClock(a.GetServerTime)
+	if err := clock.Start(ctx, interval); err != nil {
+		return nil, err
+	}
+
+	a.client.SetClock(clock)
+	return clock, nil
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lajosdeme/polymarket-go-api/client"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+func TestRotateAPIKeyKeepsNonceOnFailure(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/time":
+			w.Write([]byte("1700000000"))
+		case "/auth/api-key":
+			if fail.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`{"apiKey":"key-1","secret":"c2VjcmV0","passphrase":"passphrase"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClobClient(server.URL, client.WithRetryPolicy(client.NoRetryPolicy()))
+	if err := c.SetupL1Auth(testPrivateKey, types.EOA, ""); err != nil {
+		t.Fatal(err)
+	}
+	address := c.GetAuthManager().GetAddress()
+
+	path := filepath.Join(t.TempDir(), "nonces.json")
+	nonces, err := NewNonceManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authAPI := NewAuthAPI(c)
+	authAPI.SetNonceManager(nonces)
+
+	if _, err := authAPI.RotateAPIKey(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	if nonce := authAPI.Nonce(); nonce != 0 {
+		t.Errorf("nonce = %d after a failed rotation, want 0", nonce)
+	}
+
+	fail.Store(false)
+	creds, err := authAPI.RotateAPIKey(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.APIKey != "key-1" || c.GetAuthManager().GetAPICredentials().APIKey != "key-1" {
+		t.Errorf("rotated credentials %+v not installed", creds)
+	}
+
+	// The nonce of the created credentials survives a restart
+	reloaded, err := NewNonceManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce := reloaded.Nonce(address); nonce != 1 {
+		t.Errorf("persisted nonce = %d, want 1", nonce)
+	}
+}
+
+// keyServer serves the auth endpoints of a CLOB that knows the API key valid
+type keyServer struct {
+	valid   string
+	derived atomic.Int32
+}
+
+func (s *keyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/time":
+		w.Write([]byte("1700000000"))
+	case "/auth/derive-api-key":
+		s.derived.Add(1)
+		w.Write([]byte(`{"apiKey":"` + s.valid + `","secret":"c2VjcmV0","passphrase":"passphrase"}`))
+	case "/auth/api-key":
+		if r.Method != http.MethodDelete || r.Header.Get("POLY_API_KEY") != s.valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`"OK"`))
+	case "/auth/api-keys":
+		if r.Header.Get("POLY_API_KEY") != s.valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"apiKeys":["` + s.valid + `"]}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestBootstrapL2AuthStoredCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		stored  string
+		derived int32
+	}{
+		{"valid stored key", "key-1", 0},
+		{"deleted stored key", "key-0", 1},
+	}
+
+	for _, tt := range tests {
+		keys := &keyServer{valid: "key-1"}
+		server := httptest.NewServer(keys)
+
+		c := client.NewClobClient(server.URL, client.WithRetryPolicy(client.NoRetryPolicy()))
+		if err := c.SetupL1Auth(testPrivateKey, types.EOA, ""); err != nil {
+			t.Fatal(err)
+		}
+		address := c.GetAuthManager().GetAddress()
+
+		store := NewFileCredentialStore(filepath.Join(t.TempDir(), "credentials.json"))
+		if err := store.Save(address, types.APICredentials{APIKey: tt.stored, Secret: "c2VjcmV0", Passphrase: "passphrase"}); err != nil {
+			t.Fatal(err)
+		}
+
+		var installed []string
+		c.GetAuthManager().OnCredentialsRotated(func(creds types.APICredentials) {
+			installed = append(installed, creds.APIKey)
+		})
+
+		creds, err := NewAuthAPI(c).BootstrapL2Auth(context.Background(), store)
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		if creds.APIKey != "key-1" || c.GetAuthManager().GetAPICredentials().APIKey != "key-1" {
+			t.Errorf("%s: installed %+v, want key-1", tt.name, c.GetAuthManager().GetAPICredentials())
+		}
+		// Rejected stored credentials never reach rotation listeners
+		if len(installed) != 1 || installed[0] != "key-1" {
+			t.Errorf("%s: rotation listeners saw %v, want [key-1]", tt.name, installed)
+		}
+		if got := keys.derived.Load(); got != tt.derived {
+			t.Errorf("%s: derived %d times, want %d", tt.name, got, tt.derived)
+		}
+		if saved, err := store.Load(address); err != nil || saved == nil || saved.APIKey != "key-1" {
+			t.Errorf("%s: stored %+v, %v, want key-1", tt.name, saved, err)
+		}
+	}
+}
+
+func TestDeleteAPIKeyForgetsCredentials(t *testing.T) {
+	server := httptest.NewServer(&keyServer{valid: "key-1"})
+	defer server.Close()
+
+	c := client.NewClobClient(server.URL, client.WithRetryPolicy(client.NoRetryPolicy()))
+	if err := c.SetupL1Auth(testPrivateKey, types.EOA, ""); err != nil {
+		t.Fatal(err)
+	}
+	address := c.GetAuthManager().GetAddress()
+
+	store := NewFileCredentialStore(filepath.Join(t.TempDir(), "credentials.json"))
+	authAPI := NewAuthAPI(c)
+	if _, err := authAPI.BootstrapL2Auth(context.Background(), store); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := authAPI.DeleteAPIKey(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	am := c.GetAuthManager()
+	if am.HasL2Auth() || am.GetAPICredentials() != nil {
+		t.Errorf("credentials %+v still installed after deleting their key", am.GetAPICredentials())
+	}
+	if !am.HasL1Auth() {
+		t.Error("L1 authentication lost after deleting the API key")
+	}
+	if saved, err := store.Load(address); err != nil || saved != nil {
+		t.Errorf("stored credentials = %+v, %v after deleting their key, want none", saved, err)
+	}
+}
+
+func TestRotateAPIKeySkipsUsedNonces(t *testing.T) {
+	var derived atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/time":
+			w.Write([]byte("1700000000"))
+		case "/auth/derive-api-key":
+			derived.Add(1)
+			w.Write([]byte(`{"apiKey":"key-old","secret":"c2VjcmV0","passphrase":"passphrase"}`))
+		case "/auth/api-key":
+			nonce := r.Header.Get("POLY_NONCE")
+			if nonce == "1" || nonce == "2" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"NONCE_ALREADY_USED"}`))
+				return
+			}
+			w.Write([]byte(`{"apiKey":"key-` + nonce + `","secret":"c2VjcmV0","passphrase":"passphrase"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClobClient(server.URL, client.WithRetryPolicy(client.NoRetryPolicy()))
+	if err := c.SetupL1Auth(testPrivateKey, types.EOA, ""); err != nil {
+		t.Fatal(err)
+	}
+	nonces, err := NewNonceManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	authAPI := NewAuthAPI(c)
+	authAPI.SetNonceManager(nonces)
+
+	creds, err := authAPI.RotateAPIKey(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.APIKey != "key-3" || c.GetAuthManager().GetAPICredentials().APIKey != "key-3" {
+		t.Errorf("rotated to %+v, want the new key-3", creds)
+	}
+	if got := derived.Load(); got != 0 {
+		t.Errorf("rotation derived existing credentials %d times", got)
+	}
+	if nonce := authAPI.Nonce(); nonce != 3 {
+		t.Errorf("nonce = %d, want 3", nonce)
+	}
+
+	// CreateAPIKey still derives the credentials of a used nonce
+	creds, err = authAPI.CreateAPIKey(context.Background(), 1)
+	if err != nil || creds.APIKey != "key-old" || derived.Load() != 1 {
+		t.Errorf("CreateAPIKey() of a used nonce = %+v, %v", creds, err)
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// CredentialStore persists API credentials per signer address
+type CredentialStore interface {
+	// Load returns the credentials of an address, or nil if none are stored
+	Load(address string) (*types.APICredentials, error)
+	// Save stores the credentials of an address
+	Save(address string, creds types.APICredentials) error
+	// Delete removes the credentials of an address, if any are stored
+	Delete(address string) error
+}
+
+// FileCredentialStore stores API credentials in a JSON file readable only by its owner
+type FileCredentialStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCredentialStore creates a store backed by the file at path, which is created on first save
+func NewFileCredentialStore(path string) *FileCredentialStore {
+	return &FileCredentialStore{path: path}
+}
+
+// Load implements CredentialStore
+func (s *FileCredentialStore) Load(address string) (*types.APICredentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	creds, ok := all[strings.ToLower(address)]
+	if !ok {
+		return nil, nil
+	}
+	return &creds, nil
+}
+
+// Save implements CredentialStore
+func (s *FileCredentialStore) Save(address string, creds types.APICredentials) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	all[strings.ToLower(address)] = creds
+
+	return s.write(all)
+}
+
+// Delete implements CredentialStore
+func (s *FileCredentialStore) Delete(address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[strings.ToLower(address)]; !ok {
+		return nil
+	}
+	delete(all, strings.ToLower(address))
+
+	return s.write(all)
+}
+
+// write replaces the stored credentials; s.mu must be held
+func (s *FileCredentialStore) write(all map[string]types.APICredentials) error {
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+
+	return nil
+}
+
+// read returns the stored credentials keyed by lowercase address; s.mu must be held
+func (s *FileCredentialStore) read() (map[string]types.APICredentials, error) {
+	all := make(map[string]types.APICredentials)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+	}
+	return all, nil
+}
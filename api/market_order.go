@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// CalculateMarketPrice walks the order book and returns the worst price needed to fill amount.
+// BUY orders consume asks until amount USDC is spent, SELL orders consume bids until amount shares are sold.
+// FOK orders return an error when the book is not deep enough; FAK orders take the worst available price.
+func CalculateMarketPrice(orderbook *types.Orderbook, side types.OrderSide, amount float64, orderType types.OrderType) (float64, error) {
+	if orderbook == nil {
+		return 0, fmt.Errorf("orderbook cannot be nil")
+	}
+
+	var levels []types.PriceLevel
+	switch side {
+	case types.BUY:
+		levels = orderbook.Asks
+	case types.SELL:
+		levels = orderbook.Bids
+	default:
+		return 0, fmt.Errorf("invalid order side: %q", side)
+	}
+
+	type level struct {
+		price float64
+		size  float64
+	}
+
+	parsed := make([]level, 0, len(levels))
+	for _, l := range levels {
+		price, err := strconv.ParseFloat(l.Price, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse price level %q: %w", l.Price, err)
+		}
+		size, err := strconv.ParseFloat(l.Size, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse size level %q: %w", l.Size, err)
+		}
+		parsed = append(parsed, level{price: price, size: size})
+	}
+
+	if len(parsed) == 0 {
+		return 0, fmt.Errorf("no liquidity on the %s side of the book", side)
+	}
+
+	// Best price first: lowest ask for buys, highest bid for sells
+	sort.Slice(parsed, func(i, j int) bool {
+		if side == types.BUY {
+			return parsed[i].price < parsed[j].price
+		}
+		return parsed[i].price > parsed[j].price
+	})
+
+	filled := 0.0
+	for _, l := range parsed {
+		if side == types.BUY {
+			filled += l.size * l.price
+		} else {
+			filled += l.size
+		}
+		if filled >= amount {
+			return l.price, nil
+		}
+	}
+
+	if orderType == types.FOK {
+		return 0, &ClobError{
+			Code:    ErrFOKOrderNotFilled,
+			Message: "Insufficient order book depth to fill FOK order",
+			Details: fmt.Sprintf("requested %v but the book only fills %v", amount, filled),
+		}
+	}
+
+	return parsed[len(parsed)-1].price, nil
+}
+
+// BuildMarketOrder creates a signed FOK or FAK order priced at the worst level
+// of the current order book needed to fill args.Amount
+func (b *OrderBuilder) BuildMarketOrder(ctx context.Context, args types.MarketOrderArgs, options *types.CreateOrderOptions) (*types.Order, error) {
+	// Validate required L1 authentication
+	if !b.client.GetAuthManager().HasL1Auth() {
+		return nil, fmt.Errorf("L1 authentication required for building orders")
+	}
+
+	if args.TokenID == "" {
+		return nil, fmt.Errorf("token ID cannot be empty")
+	}
+	if args.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive, got %v", args.Amount)
+	}
+	if args.FeeRateBps < 0 {
+		return nil, fmt.Errorf("fee rate cannot be negative")
+	}
+
+	orderType := args.OrderType
+	if orderType == "" {
+		orderType = types.FOK
+	}
+	if orderType != types.FOK && orderType != types.FAK {
+		return nil, fmt.Errorf("market orders must be FOK or FAK, got %s", orderType)
+	}
+
+	orderbook, err := b.orderbook.GetOrderbook(ctx, args.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orderbook: %w", err)
+	}
+	if _, err := b.recordOrderbook(args.TokenID, orderbook); err != nil {
+		return nil, err
+	}
+
+	price, err := CalculateMarketPrice(orderbook, args.Side, args.Amount, orderType)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := b.resolveOptions(ctx, args.TokenID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidatePrice(price, *resolved.TickSize); err != nil {
+		return nil, err
+	}
+
+	makerAmount, takerAmount, err := marketOrderAmounts(args.Side, args.Amount, price, *resolved.TickSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shares are the taker amount of a BUY and the maker amount of a SELL
+	shares := takerAmount
+	if args.Side == types.SELL {
+		shares = makerAmount
+	}
+	if err := ValidateSize(float64(shares)/math.Pow10(tokenDecimals), *resolved.MinOrderSize); err != nil {
+		return nil, err
+	}
+	if makerAmount == 0 || takerAmount == 0 {
+		return nil, &ClobError{
+			Code:    ErrInvalidOrderMinSize,
+			Message: "Order size below minimum threshold",
+			Details: fmt.Sprintf("amount %v rounds to zero", args.Amount),
+		}
+	}
+
+	return b.signOrder(ctx, args.TokenID, args.Side, makerAmount, takerAmount, 0, args.Nonce, args.FeeRateBps, args.Taker, *resolved.NegRisk)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lajosdeme/polymarket-go-api/client"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// testPrivateKey is a well-known development key, never used on mainnet
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+func TestBuildMarketOrderMinSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"asset_id":"1","bids":[{"price":"0.5","size":"100"}],"asks":[{"price":"0.5","size":"100"}],"min_order_size":"5","tick_size":"0.01","neg_risk":false}`))
+	}))
+	defer server.Close()
+
+	c := client.NewClobClient(server.URL, client.WithRetryPolicy(client.NoRetryPolicy()))
+	if err := c.SetupL1Auth(testPrivateKey, types.EOA, ""); err != nil {
+		t.Fatal(err)
+	}
+	builder := NewOrderBuilder(c)
+
+	tests := []struct {
+		name   string
+		side   types.OrderSide
+		amount float64
+		valid  bool
+	}{
+		{"sell at minimum", types.SELL, 5, true},
+		{"sell below minimum", types.SELL, 4.99, false},
+		{"buy at minimum", types.BUY, 2.5, true},
+		{"buy below minimum", types.BUY, 2.49, false},
+	}
+
+	for _, tt := range tests {
+		_, err := builder.BuildMarketOrder(context.Background(), types.MarketOrderArgs{
+			TokenID: "1",
+			Side:    tt.side,
+			Amount:  tt.amount,
+		}, nil)
+		if tt.valid {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		clobErr, ok := types.AsClobError(err)
+		if !ok || clobErr.Code != ErrInvalidOrderMinSize {
+			t.Errorf("%s: got %v, want %s", tt.name, err, ErrInvalidOrderMinSize)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// NonceManager tracks the L1 nonce of the API credentials of each address and persists it
+// to a file, so the credentials can be derived again after a restart. It is safe for concurrent use.
+type NonceManager struct {
+	path   string
+	mu     sync.Mutex
+	nonces map[string]uint64
+}
+
+// NewNonceManager creates a nonce manager persisted to the file at path, loading the nonces
+// stored there. An empty path keeps the nonces in memory only.
+func NewNonceManager(path string) (*NonceManager, error) {
+	m := &NonceManager{
+		path:   path,
+		nonces: make(map[string]uint64),
+	}
+
+	if path == "" {
+		return m, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read nonces: %w", err)
+	}
+	if err := json.Unmarshal(data, &m.nonces); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal nonces: %w", err)
+	}
+
+	return m, nil
+}
+
+// Nonce returns the nonce of the current API credentials of an address, zero if none is recorded
+func (m *NonceManager) Nonce(address string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.nonces[strings.ToLower(address)]
+}
+
+// SetNonce records the nonce of the current API credentials of an address
+func (m *NonceManager) SetNonce(address string, nonce uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.set(address, nonce)
+}
+
+// Next returns the nonce following the current one of an address, for creating a new set of
+// API credentials. It is not recorded, so a failed creation leaves the current nonce in place.
+func (m *NonceManager) Next(address string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.nonces[strings.ToLower(address)] + 1
+}
+
+// set records and persists a nonce; m.mu must be held
+func (m *NonceManager) set(address string, nonce uint64) error {
+	key := strings.ToLower(address)
+	if current, ok := m.nonces[key]; ok && current == nonce {
+		return nil
+	}
+	m.nonces[key] = nonce
+
+	if m.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(m.nonces, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal nonces: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create nonces directory: %w", err)
+	}
+
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write nonces: %w", err)
+	}
+	if err := os.Rename(tmp, m.path); err != nil {
+		return fmt.Errorf("failed to write nonces: %w", err)
+	}
+
+	return nil
+}
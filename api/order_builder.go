@@ -0,0 +1,301 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lajosdeme/polymarket-go-api/client"
+	"github.com/lajosdeme/polymarket-go-api/crypto"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// tokenDecimals is the number of decimals used by USDC and conditional tokens
+const tokenDecimals = 6
+
+// gtdSecurityThreshold is the minimum lifetime the CLOB requires for GTD orders
+const gtdSecurityThreshold = time.Minute
+
+// OrderBuilder creates and signs CTF Exchange orders
+type OrderBuilder struct {
+	client    *client.ClobClient
+	orderbook *OrderbookAPI
+
+	mu      sync.RWMutex
+	markets map[string]types.CreateOrderOptions
+}
+
+// NewOrderBuilder creates a new OrderBuilder instance
+func NewOrderBuilder(client *client.ClobClient) *OrderBuilder {
+	return &OrderBuilder{
+		client:    client,
+		orderbook: NewOrderbookAPI(client),
+		markets:   make(map[string]types.CreateOrderOptions),
+	}
+}
+
+// SetNegRisk records whether a token trades on the neg-risk exchange
+func (b *OrderBuilder) SetNegRisk(tokenID string, negRisk bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	market := b.markets[tokenID]
+	market.NegRisk = &negRisk
+	b.markets[tokenID] = market
+}
+
+// SetTickSize records the tick size of a token, e.g. after a tick_size_change event
+func (b *OrderBuilder) SetTickSize(tokenID string, tickSize types.TickSize) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	market := b.markets[tokenID]
+	market.TickSize = &tickSize
+	b.markets[tokenID] = market
+}
+
+// SetMinOrderSize records the minimum order size of a token
+func (b *OrderBuilder) SetMinOrderSize(tokenID string, minOrderSize float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	market := b.markets[tokenID]
+	market.MinOrderSize = &minOrderSize
+	b.markets[tokenID] = market
+}
+
+// LoadGammaMarket records the neg-risk flag, tick size and minimum order size
+// of every token of a Gamma market. Values the market does not report, e.g. the
+// neg-risk flag of a market without events, are left to be fetched from the order book.
+func (b *OrderBuilder) LoadGammaMarket(market *types.GammaMarket) error {
+	if market == nil {
+		return fmt.Errorf("market cannot be nil")
+	}
+	if market.ClobTokenIds == nil || *market.ClobTokenIds == "" {
+		return fmt.Errorf("market %s has no CLOB token IDs", market.ID)
+	}
+
+	var tokenIDs []string
+	if err := json.Unmarshal([]byte(*market.ClobTokenIds), &tokenIDs); err != nil {
+		return fmt.Errorf("failed to parse CLOB token IDs: %w", err)
+	}
+
+	// The flag of the market or its events; a true flag wins
+	negRisk := market.NegRisk
+	for _, event := range market.Events {
+		if event.NegRisk != nil && (negRisk == nil || *event.NegRisk) {
+			negRisk = event.NegRisk
+		}
+	}
+
+	for _, tokenID := range tokenIDs {
+		if negRisk != nil {
+			b.SetNegRisk(tokenID, *negRisk)
+		}
+		if market.OrderPriceMinTickSize != nil {
+			b.SetTickSize(tokenID, types.TickSize(strconv.FormatFloat(*market.OrderPriceMinTickSize, 'f', -1, 64)))
+		}
+		if market.OrderMinSize != nil {
+			b.SetMinOrderSize(tokenID, *market.OrderMinSize)
+		}
+	}
+
+	return nil
+}
+
+// GetNegRisk returns whether a token trades on the neg-risk exchange,
+// fetching its order book when the flag is not known yet
+func (b *OrderBuilder) GetNegRisk(ctx context.Context, tokenID string) (bool, error) {
+	options, err := b.resolveOptions(ctx, tokenID, nil)
+	if err != nil {
+		return false, err
+	}
+	return *options.NegRisk, nil
+}
+
+// GetTickSize returns the tick size of a token,
+// fetching its order book when the tick size is not known yet
+func (b *OrderBuilder) GetTickSize(ctx context.Context, tokenID string) (types.TickSize, error) {
+	options, err := b.resolveOptions(ctx, tokenID, nil)
+	if err != nil {
+		return "", err
+	}
+	return *options.TickSize, nil
+}
+
+// BuildOrder creates a signed limit order from the given arguments.
+// Options not provided are resolved from the market of the token.
+// The price is snapped to the tick size and the size rounded down to two decimals;
+// orders that would be rejected for tick size or minimum size return a *ClobError.
+func (b *OrderBuilder) BuildOrder(ctx context.Context, args types.OrderArgs, options *types.CreateOrderOptions) (*types.Order, error) {
+	authManager := b.client.GetAuthManager()
+
+	// Validate required L1 authentication
+	if !authManager.HasL1Auth() {
+		return nil, fmt.Errorf("L1 authentication required for building orders")
+	}
+
+	if args.TokenID == "" {
+		return nil, fmt.Errorf("token ID cannot be empty")
+	}
+	if err := b.validateExpiration(args.Expiration); err != nil {
+		return nil, err
+	}
+	if args.FeeRateBps < 0 {
+		return nil, fmt.Errorf("fee rate cannot be negative")
+	}
+
+	resolved, err := b.resolveOptions(ctx, args.TokenID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidatePrice(args.Price, *resolved.TickSize); err != nil {
+		return nil, err
+	}
+	if err := ValidateSize(args.Size, *resolved.MinOrderSize); err != nil {
+		return nil, err
+	}
+
+	makerAmount, takerAmount, err := limitOrderAmounts(args.Side, args.Price, args.Size, *resolved.TickSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.signOrder(ctx, args.TokenID, args.Side, makerAmount, takerAmount, args.Expiration, args.Nonce, args.FeeRateBps, args.Taker, *resolved.NegRisk)
+}
+
+// GTDExpiration returns the expiration of a GTD order that stays live for d, measured on the
+// client's clock. It includes the one minute security threshold enforced by the CLOB.
+func (b *OrderBuilder) GTDExpiration(d time.Duration) int64 {
+	return b.client.Now().Add(gtdSecurityThreshold + d).Unix()
+}
+
+// validateExpiration checks that an expiration is zero or beyond the security threshold
+func (b *OrderBuilder) validateExpiration(expiration int64) error {
+	if expiration < 0 {
+		return fmt.Errorf("expiration cannot be negative")
+	}
+
+	if expiration != 0 && expiration <= b.client.Now().Add(gtdSecurityThreshold).Unix() {
+		return &ClobError{
+			Code:    ErrInvalidOrderExpiration,
+			Message: fmt.Sprintf("expiration %d must be more than %s in the future", expiration, gtdSecurityThreshold),
+		}
+	}
+
+	return nil
+}
+
+// signOrder assembles an order from computed amounts and signs it against the matching exchange
+func (b *OrderBuilder) signOrder(ctx context.Context, tokenID string, side types.OrderSide, makerAmount, takerAmount, expiration int64, nonce uint64, feeRateBps int, taker string, negRisk bool) (*types.Order, error) {
+	authManager := b.client.GetAuthManager()
+
+	salt, err := crypto.GenerateSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	if taker == "" {
+		taker = crypto.ZeroAddress
+	}
+
+	// Proxy and Safe wallets hold the funds, the EOA only signs
+	order := &types.Order{
+		Salt:          strconv.FormatUint(salt, 10),
+		Maker:         authManager.GetFunder(),
+		Signer:        authManager.GetAddress(),
+		Taker:         taker,
+		TokenID:       tokenID,
+		MakerAmount:   strconv.FormatInt(makerAmount, 10),
+		TakerAmount:   strconv.FormatInt(takerAmount, 10),
+		Expiration:    strconv.FormatInt(expiration, 10),
+		Nonce:         strconv.FormatUint(nonce, 10),
+		FeeRateBps:    strconv.Itoa(feeRateBps),
+		Side:          side,
+		SignatureType: int(authManager.GetSignatureType()),
+	}
+
+	if err := authManager.SignOrder(ctx, order, negRisk); err != nil {
+		return nil, fmt.Errorf("failed to sign order: %w", err)
+	}
+
+	return order, nil
+}
+
+// resolveOptions merges explicit options over the known market parameters of a token
+// and fetches the order book when any of them is still missing
+func (b *OrderBuilder) resolveOptions(ctx context.Context, tokenID string, options *types.CreateOrderOptions) (types.CreateOrderOptions, error) {
+	b.mu.RLock()
+	resolved := b.markets[tokenID]
+	b.mu.RUnlock()
+
+	if options != nil {
+		if options.TickSize != nil {
+			resolved.TickSize = options.TickSize
+		}
+		if options.MinOrderSize != nil {
+			resolved.MinOrderSize = options.MinOrderSize
+		}
+		if options.NegRisk != nil {
+			resolved.NegRisk = options.NegRisk
+		}
+	}
+
+	if resolved.TickSize != nil && resolved.MinOrderSize != nil && resolved.NegRisk != nil {
+		return resolved, nil
+	}
+
+	orderbook, err := b.orderbook.GetOrderbook(ctx, tokenID)
+	if err != nil {
+		return types.CreateOrderOptions{}, fmt.Errorf("failed to get orderbook: %w", err)
+	}
+
+	market, err := b.recordOrderbook(tokenID, orderbook)
+	if err != nil {
+		return types.CreateOrderOptions{}, err
+	}
+
+	if resolved.TickSize == nil {
+		resolved.TickSize = market.TickSize
+	}
+	if resolved.MinOrderSize == nil {
+		resolved.MinOrderSize = market.MinOrderSize
+	}
+	if resolved.NegRisk == nil {
+		resolved.NegRisk = market.NegRisk
+	}
+
+	return resolved, nil
+}
+
+// recordOrderbook records the market parameters reported by an order book
+func (b *OrderBuilder) recordOrderbook(tokenID string, orderbook *types.Orderbook) (types.CreateOrderOptions, error) {
+	tickSize := types.TickSize(orderbook.TickSize)
+	if _, err := TickSizeDecimals(tickSize); err != nil {
+		return types.CreateOrderOptions{}, err
+	}
+
+	minOrderSize := 0.0
+	if orderbook.MinOrderSize != "" {
+		var err error
+		minOrderSize, err = strconv.ParseFloat(orderbook.MinOrderSize, 64)
+		if err != nil {
+			return types.CreateOrderOptions{}, fmt.Errorf("failed to parse min order size: %w", err)
+		}
+	}
+	negRisk := orderbook.NegRisk
+
+	b.SetTickSize(tokenID, tickSize)
+	b.SetMinOrderSize(tokenID, minOrderSize)
+	b.SetNegRisk(tokenID, negRisk)
+
+	return types.CreateOrderOptions{
+		TickSize:     &tickSize,
+		MinOrderSize: &minOrderSize,
+		NegRisk:      &negRisk,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lajosdeme/polymarket-go-api/client"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+func TestLoadGammaMarketNegRisk(t *testing.T) {
+	yes, no := true, false
+	tokenIDs := `["1","2"]`
+	tickSize, minSize := 0.01, 5.0
+
+	tests := []struct {
+		name    string
+		market  types.GammaMarket
+		negRisk bool
+		lookups int32
+	}{
+		{"market flag", types.GammaMarket{ID: "1", ClobTokenIds: &tokenIDs, OrderPriceMinTickSize: &tickSize, OrderMinSize: &minSize, NegRisk: &yes}, true, 0},
+		{"event flag", types.GammaMarket{ID: "1", ClobTokenIds: &tokenIDs, OrderPriceMinTickSize: &tickSize, OrderMinSize: &minSize, Events: []types.GammaEvent{{NegRisk: &no}, {NegRisk: &yes}}}, true, 0},
+		{"events without the flag", types.GammaMarket{ID: "1", ClobTokenIds: &tokenIDs, OrderPriceMinTickSize: &tickSize, OrderMinSize: &minSize, Events: []types.GammaEvent{{ID: "2"}}}, true, 1},
+		{"no events", types.GammaMarket{ID: "1", ClobTokenIds: &tokenIDs, OrderPriceMinTickSize: &tickSize, OrderMinSize: &minSize}, true, 1},
+		{"not neg-risk", types.GammaMarket{ID: "1", ClobTokenIds: &tokenIDs, OrderPriceMinTickSize: &tickSize, OrderMinSize: &minSize, NegRisk: &no}, false, 0},
+	}
+
+	for _, tt := range tests {
+		var lookups atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lookups.Add(1)
+			w.Write([]byte(`{"asset_id":"1","bids":[],"asks":[],"min_order_size":"5","tick_size":"0.01","neg_risk":true}`))
+		}))
+
+		builder := NewOrderBuilder(client.NewClobClient(server.URL, client.WithRetryPolicy(client.NoRetryPolicy())))
+		if err := builder.LoadGammaMarket(&tt.market); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		negRisk, err := builder.GetNegRisk(context.Background(), "1")
+		server.Close()
+
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if negRisk != tt.negRisk {
+			t.Errorf("%s: neg-risk = %v, want %v", tt.name, negRisk, tt.negRisk)
+		}
+		if got := lookups.Load(); got != tt.lookups {
+			t.Errorf("%s: %d order book lookups, want %d", tt.name, got, tt.lookups)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// sizeDecimals is the number of decimals order sizes are rounded to
+const sizeDecimals = 2
+
+// roundingEpsilon absorbs floating point error when rounding down
+const roundingEpsilon = 1e-9
+
+// TickSizeDecimals returns the number of price decimals allowed by a tick size
+func TickSizeDecimals(tickSize types.TickSize) (int, error) {
+	switch tickSize {
+	case types.TickSize01:
+		return 1, nil
+	case types.TickSize001:
+		return 2, nil
+	case types.TickSize0001:
+		return 3, nil
+	case types.TickSize00001:
+		return 4, nil
+	default:
+		return 0, fmt.Errorf("unsupported tick size: %q", tickSize)
+	}
+}
+
+// RoundPrice snaps a price to the nearest multiple of the tick size
+func RoundPrice(price float64, tickSize types.TickSize) (float64, error) {
+	decimals, err := TickSizeDecimals(tickSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(priceUnits(price, decimals)) / math.Pow10(decimals), nil
+}
+
+// ValidatePrice checks that a price lies within [tickSize, 1 - tickSize]
+func ValidatePrice(price float64, tickSize types.TickSize) error {
+	decimals, err := TickSizeDecimals(tickSize)
+	if err != nil {
+		return err
+	}
+
+	tick := math.Pow10(-decimals)
+	if price < tick-roundingEpsilon || price > 1-tick+roundingEpsilon {
+		return &ClobError{
+			Code:    ErrInvalidOrderMinTickSize,
+			Message: "Order price breaks minimum tick size rules",
+			Details: fmt.Sprintf("price %v must be between %v and %v for tick size %s", price, tick, 1-tick, tickSize),
+		}
+	}
+
+	return nil
+}
+
+// ValidateSize checks that a size, rounded down to the size precision, meets the market minimum
+func ValidateSize(size, minOrderSize float64) error {
+	rounded := float64(sizeUnits(size)) / math.Pow10(sizeDecimals)
+	if rounded <= 0 || rounded < minOrderSize {
+		return &ClobError{
+			Code:    ErrInvalidOrderMinSize,
+			Message: "Order size below minimum threshold",
+			Details: fmt.Sprintf("size %v is lower than the minimum of %v", size, minOrderSize),
+		}
+	}
+
+	return nil
+}
+
+// limitOrderAmounts computes the maker and taker amounts of a limit order in token units.
+// The price is snapped to the tick size and the size rounded down to two decimals, so the
+// resulting collateral amount never has more decimals than the exchange accepts.
+func limitOrderAmounts(side types.OrderSide, price, size float64, tickSize types.TickSize) (int64, int64, error) {
+	decimals, err := TickSizeDecimals(tickSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// price * size is exact at 10^(decimals + sizeDecimals) and scaled up to token decimals
+	shares := sizeUnits(size) * pow10Int(tokenDecimals-sizeDecimals)
+	collateral := priceUnits(price, decimals) * sizeUnits(size) * pow10Int(tokenDecimals-decimals-sizeDecimals)
+
+	switch side {
+	case types.BUY:
+		return collateral, shares, nil
+	case types.SELL:
+		return shares, collateral, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid order side: %q", side)
+	}
+}
+
+// marketOrderAmounts computes the maker and taker amounts of a market order in token units.
+// BUY amounts are in USDC and SELL amounts in shares; both are rounded down to two decimals,
+// and the counter amount is rounded down to the precision allowed by the tick size.
+func marketOrderAmounts(side types.OrderSide, amount, price float64, tickSize types.TickSize) (int64, int64, error) {
+	decimals, err := TickSizeDecimals(tickSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	amountUnits := sizeUnits(amount)
+	price10 := priceUnits(price, decimals)
+	if price10 <= 0 {
+		return 0, 0, fmt.Errorf("price must be positive, got %v", price)
+	}
+	scale := pow10Int(tokenDecimals - decimals - sizeDecimals)
+
+	switch side {
+	case types.BUY:
+		// shares = amount / price, floored at 10^(decimals + sizeDecimals)
+		shares := amountUnits * pow10Int(2*decimals) / price10
+		return amountUnits * pow10Int(tokenDecimals-sizeDecimals), shares * scale, nil
+	case types.SELL:
+		collateral := amountUnits * price10
+		return amountUnits * pow10Int(tokenDecimals-sizeDecimals), collateral * scale, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid order side: %q", side)
+	}
+}
+
+// priceUnits rounds a price to the given number of decimals and returns it as an integer
+func priceUnits(price float64, decimals int) int64 {
+	return int64(math.Round(price * math.Pow10(decimals)))
+}
+
+// sizeUnits rounds a size down to sizeDecimals and returns it as an integer
+func sizeUnits(size float64) int64 {
+	return int64(math.Floor(size*math.Pow10(sizeDecimals) + roundingEpsilon))
+}
+
+// pow10Int returns 10^n as an integer
+func pow10Int(n int) int64 {
+	result := int64(1)
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
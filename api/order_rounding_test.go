@@ -0,0 +1,189 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+func TestRoundPrice(t *testing.T) {
+	tests := []struct {
+		price    float64
+		tickSize types.TickSize
+		want     float64
+	}{
+		{0.26, types.TickSize01, 0.3},
+		{0.24, types.TickSize01, 0.2},
+		{0.5649, types.TickSize001, 0.56},
+		{0.5651, types.TickSize001, 0.57},
+		{0.5651, types.TickSize0001, 0.565},
+		{0.12346, types.TickSize00001, 0.1235},
+	}
+
+	for _, tt := range tests {
+		got, err := RoundPrice(tt.price, tt.tickSize)
+		if err != nil {
+			t.Fatalf("RoundPrice(%v, %s): %v", tt.price, tt.tickSize, err)
+		}
+		if got != tt.want {
+			t.Errorf("RoundPrice(%v, %s) = %v, want %v", tt.price, tt.tickSize, got, tt.want)
+		}
+	}
+
+	if _, err := RoundPrice(0.5, "0.05"); err == nil {
+		t.Error("RoundPrice with unsupported tick size: expected error")
+	}
+}
+
+func TestValidatePrice(t *testing.T) {
+	tests := []struct {
+		price    float64
+		tickSize types.TickSize
+		valid    bool
+	}{
+		{0.1, types.TickSize01, true},
+		{0.9, types.TickSize01, true},
+		{0.05, types.TickSize01, false},
+		{0.95, types.TickSize01, false},
+		{0.01, types.TickSize001, true},
+		{0.99, types.TickSize001, true},
+		{1 - 0.99, types.TickSize001, true},
+		{0.009, types.TickSize001, false},
+		{0.991, types.TickSize001, false},
+		{0, types.TickSize001, false},
+		{1, types.TickSize001, false},
+		{0.001, types.TickSize0001, true},
+		{0.999, types.TickSize0001, true},
+		{0.0009, types.TickSize0001, false},
+		{0.9995, types.TickSize0001, false},
+		{0.0001, types.TickSize00001, true},
+		{0.9999, types.TickSize00001, true},
+		{0.00005, types.TickSize00001, false},
+		{0.99995, types.TickSize00001, false},
+	}
+
+	for _, tt := range tests {
+		err := ValidatePrice(tt.price, tt.tickSize)
+		if tt.valid {
+			if err != nil {
+				t.Errorf("ValidatePrice(%v, %s): unexpected error %v", tt.price, tt.tickSize, err)
+			}
+			continue
+		}
+		clobErr, ok := types.AsClobError(err)
+		if !ok || clobErr.Code != ErrInvalidOrderMinTickSize {
+			t.Errorf("ValidatePrice(%v, %s) = %v, want %s", tt.price, tt.tickSize, err, ErrInvalidOrderMinTickSize)
+		}
+	}
+}
+
+func TestValidateSize(t *testing.T) {
+	tests := []struct {
+		size    float64
+		minSize float64
+		valid   bool
+	}{
+		{5, 5, true},
+		{5.009, 5, true},
+		{4.999, 5, false},
+		{0.29, 0.29, true},
+		{0.004, 0, false},
+		{0, 0, false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSize(tt.size, tt.minSize)
+		if tt.valid {
+			if err != nil {
+				t.Errorf("ValidateSize(%v, %v): unexpected error %v", tt.size, tt.minSize, err)
+			}
+			continue
+		}
+		clobErr, ok := types.AsClobError(err)
+		if !ok || clobErr.Code != ErrInvalidOrderMinSize {
+			t.Errorf("ValidateSize(%v, %v) = %v, want %s", tt.size, tt.minSize, err, ErrInvalidOrderMinSize)
+		}
+	}
+}
+
+func TestLimitOrderAmounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		side     types.OrderSide
+		price    float64
+		size     float64
+		tickSize types.TickSize
+		maker    int64
+		taker    int64
+	}{
+		{"buy tick 0.1", types.BUY, 0.3, 7.5, types.TickSize01, 2_250_000, 7_500_000},
+		{"buy tick 0.01", types.BUY, 0.56, 21.04, types.TickSize001, 11_782_400, 21_040_000},
+		{"buy tick 0.001", types.BUY, 0.123, 3.33, types.TickSize0001, 409_590, 3_330_000},
+		{"buy tick 0.0001", types.BUY, 0.0057, 100, types.TickSize00001, 570_000, 100_000_000},
+		{"sell tick 0.1", types.SELL, 0.3, 7.5, types.TickSize01, 7_500_000, 2_250_000},
+		{"sell tick 0.01", types.SELL, 0.56, 21.04, types.TickSize001, 21_040_000, 11_782_400},
+		{"sell tick 0.001", types.SELL, 0.123, 3.33, types.TickSize0001, 3_330_000, 409_590},
+		{"sell tick 0.0001", types.SELL, 0.0057, 100, types.TickSize00001, 100_000_000, 570_000},
+		{"price snapped to tick", types.BUY, 0.5649, 10, types.TickSize001, 5_600_000, 10_000_000},
+		{"size just under a cent", types.BUY, 0.5, 10.999, types.TickSize001, 5_495_000, 10_990_000},
+		{"size with float error", types.SELL, 0.5, 0.29, types.TickSize001, 290_000, 145_000},
+		{"size rounded down", types.SELL, 0.5, 1.005, types.TickSize001, 1_000_000, 500_000},
+	}
+
+	for _, tt := range tests {
+		maker, taker, err := limitOrderAmounts(tt.side, tt.price, tt.size, tt.tickSize)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if maker != tt.maker || taker != tt.taker {
+			t.Errorf("%s: got maker %d taker %d, want maker %d taker %d", tt.name, maker, taker, tt.maker, tt.taker)
+		}
+	}
+
+	if _, _, err := limitOrderAmounts("HOLD", 0.5, 10, types.TickSize001); err == nil {
+		t.Error("invalid side: expected error")
+	}
+	if _, _, err := limitOrderAmounts(types.BUY, 0.5, 10, "0.05"); err == nil {
+		t.Error("unsupported tick size: expected error")
+	}
+}
+
+func TestMarketOrderAmounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		side     types.OrderSide
+		amount   float64
+		price    float64
+		tickSize types.TickSize
+		maker    int64
+		taker    int64
+	}{
+		{"buy tick 0.1", types.BUY, 100, 0.3, types.TickSize01, 100_000_000, 333_333_000},
+		{"buy tick 0.01", types.BUY, 10, 0.56, types.TickSize001, 10_000_000, 17_857_100},
+		{"buy tick 0.001", types.BUY, 5, 0.123, types.TickSize0001, 5_000_000, 40_650_400},
+		{"buy tick 0.0001", types.BUY, 1, 0.0057, types.TickSize00001, 1_000_000, 175_438_596},
+		{"sell tick 0.1", types.SELL, 7.5, 0.3, types.TickSize01, 7_500_000, 2_250_000},
+		{"sell tick 0.01", types.SELL, 10, 0.56, types.TickSize001, 10_000_000, 5_600_000},
+		{"sell tick 0.001", types.SELL, 3.339, 0.123, types.TickSize0001, 3_330_000, 409_590},
+		{"sell tick 0.0001", types.SELL, 100, 0.0057, types.TickSize00001, 100_000_000, 570_000},
+		{"buy amount just under a cent", types.BUY, 9.999, 0.5, types.TickSize001, 9_990_000, 19_980_000},
+		{"sell amount with float error", types.SELL, 0.29, 0.5, types.TickSize001, 290_000, 145_000},
+	}
+
+	for _, tt := range tests {
+		maker, taker, err := marketOrderAmounts(tt.side, tt.amount, tt.price, tt.tickSize)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if maker != tt.maker || taker != tt.taker {
+			t.Errorf("%s: got maker %d taker %d, want maker %d taker %d", tt.name, maker, taker, tt.maker, tt.taker)
+		}
+	}
+
+	if _, _, err := marketOrderAmounts(types.BUY, 10, 0, types.TickSize001); err == nil {
+		t.Error("zero price: expected error")
+	}
+	if _, _, err := marketOrderAmounts("HOLD", 10, 0.5, types.TickSize001); err == nil {
+		t.Error("invalid side: expected error")
+	}
+}
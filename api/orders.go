@@ -21,7 +21,8 @@ func NewOrdersAPI(client *client.ClobClient) *OrdersAPI {
 	}
 }
 
-// PlaceOrder places a single order
+// PlaceOrder places a single order.
+// When the CLOB answers with success=false the response is returned together with a *ClobError.
 func (o *OrdersAPI) PlaceOrder(ctx context.Context, order types.PostOrder) (*types.OrderResponse, error) {
 	// Validate required L2 authentication
 	if !o.client.GetAuthManager().HasL2Auth() {
@@ -30,6 +31,7 @@ func (o *OrdersAPI) PlaceOrder(ctx context.Context, order types.PostOrder) (*typ
 
 	body, err := o.client.DoRequest(ctx, "POST", "/order", order, true)
 	if err != nil {
+		o.client.ObserveOrders(nil, err)
 		return nil, err
 	}
 
@@ -37,10 +39,26 @@ func (o *OrdersAPI) PlaceOrder(ctx context.Context, order types.PostOrder) (*typ
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	o.client.ObserveOrders([]types.OrderResponse{response}, nil)
+
+	if clobErr := types.NewOrderResponseError(&response); clobErr != nil {
+		clobErr.Method = "POST"
+		clobErr.Path = "/order"
+		return &response, clobErr
+	}
 
 	return &response, nil
 }
 
+// OrderResponseError maps an unsuccessful order response, e.g. one entry of a
+// PlaceOrders batch, to a *ClobError. It returns nil for successful responses.
+func OrderResponseError(response *types.OrderResponse) error {
+	if clobErr := types.NewOrderResponseError(response); clobErr != nil {
+		return clobErr
+	}
+	return nil
+}
+
 // PlaceOrders places multiple orders (batch)
 func (o *OrdersAPI) PlaceOrders(ctx context.Context, orders []types.PostOrder) ([]types.OrderResponse, error) {
 	// Validate required L2 authentication
@@ -50,6 +68,9 @@ func (o *OrdersAPI) PlaceOrders(ctx context.Context, orders []types.PostOrder) (
 
 	body, err := o.client.DoRequest(ctx, "POST", "/orders", orders, true)
 	if err != nil {
+		for range orders {
+			o.client.ObserveOrders(nil, err)
+		}
 		return nil, err
 	}
 
@@ -57,6 +78,7 @@ func (o *OrdersAPI) PlaceOrders(ctx context.Context, orders []types.PostOrder) (
 	if err := json.Unmarshal(body, &responses); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	o.client.ObserveOrders(responses, nil)
 
 	return responses, nil
 }
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/lajosdeme/polymarket-go-api/cassette"
+	"github.com/lajosdeme/polymarket-go-api/client"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// Token and condition IDs of the market in testdata/cassettes. The cassettes hold redacted
+// CLOB and Gamma responses; record new ones with cassette.ModeRecord against the live API.
+const (
+	replayCondition = "0x5f65177b394277fd294cd75650044e32ba009a95022d88a0c1d565897d72f8f1"
+	replayYesToken  = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	replayNoToken   = "52114319501245915516055106046884209969926127482827954674443846427813813222426"
+	replayOrderID   = "0xb2f1c4e3a7d6f5e8c9b0a1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2"
+)
+
+// replayClobClient returns an L2 authenticated ClobClient replaying testdata/cassettes/clob.json
+func replayClobClient(t *testing.T) *client.ClobClient {
+	t.Helper()
+
+	rec, err := cassette.New("testdata/cassettes/clob.json", cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := client.NewClobClient("", client.WithTransport(rec), client.WithRateLimiter(nil), client.WithRetryPolicy(client.NoRetryPolicy()))
+	if err := c.SetupL1Auth(testPrivateKey, types.EOA, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetupL2Auth("key", "c2VjcmV0", "passphrase"); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestReplayMarketData(t *testing.T) {
+	c := replayClobClient(t)
+	ctx := context.Background()
+
+	timestamp, err := NewAuthAPI(c).GetServerTime(ctx)
+	if err != nil || timestamp != 1700000000 {
+		t.Errorf("GetServerTime() = %d, %v", timestamp, err)
+	}
+
+	orderbooks := NewOrderbookAPI(c)
+	book, err := orderbooks.GetOrderbook(ctx, replayYesToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Market != replayCondition || book.AssetID != replayYesToken || len(book.Bids) != 2 || book.Asks[1].Price != "0.51" || book.TickSize != "0.01" || book.MinOrderSize != "5" {
+		t.Errorf("GetOrderbook() = %+v", book)
+	}
+
+	books, err := orderbooks.GetOrderbooks(ctx, []types.OrderbooksRequest{{TokenIDs: []string{replayYesToken, replayNoToken}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(books) != 2 || books[1].AssetID != replayNoToken {
+		t.Errorf("GetOrderbooks() = %+v", books)
+	}
+
+	pricing := NewPricingAPI(c)
+	price, err := pricing.GetPrice(ctx, replayYesToken, types.BUY)
+	if err != nil || price.Price != "0.51" {
+		t.Errorf("GetPrice() = %+v, %v", price, err)
+	}
+
+	prices, err := pricing.GetPrices(ctx)
+	if err != nil || (*prices)[replayNoToken]["SELL"] != "0.48" {
+		t.Errorf("GetPrices() = %+v, %v", prices, err)
+	}
+
+	midpoint, err := pricing.GetMidpointPrice(ctx, replayYesToken)
+	if err != nil || midpoint.Mid != "0.5" {
+		t.Errorf("GetMidpointPrice() = %+v, %v", midpoint, err)
+	}
+
+	fidelity := 60
+	history, err := pricing.GetPriceHistory(ctx, types.PriceHistoryRequest{Market: replayYesToken, Interval: "1d", Fidelity: &fidelity})
+	if err != nil || len(history.History) != 2 || history.History[1].T != 1700000000 || history.History[1].P != 0.5 {
+		t.Errorf("GetPriceHistory() = %+v, %v", history, err)
+	}
+
+	spreads, err := pricing.GetSpreads(ctx, []types.SpreadsRequest{{TokenID: replayYesToken}})
+	if err != nil || (*spreads)[replayYesToken] != "0.02" {
+		t.Errorf("GetSpreads() = %+v, %v", spreads, err)
+	}
+}
+
+func TestReplayAccount(t *testing.T) {
+	c := replayClobClient(t)
+	ctx := context.Background()
+
+	keys, err := NewAuthAPI(c).GetAPIKeys(ctx)
+	if err != nil || len(keys.APIKeys) != 1 || keys.APIKeys[0] != cassette.Redacted {
+		t.Errorf("GetAPIKeys() = %+v, %v", keys, err)
+	}
+
+	orders := NewOrdersAPI(c)
+	order, err := orders.GetOrder(ctx, replayOrderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != replayOrderID || order.Side != types.BUY || order.Price != "0.45" || order.OriginalSize != "100" || order.Status != "LIVE" {
+		t.Errorf("GetOrder() = %+v", order)
+	}
+
+	active, err := orders.GetActiveOrders(ctx, "", replayCondition, "")
+	if err != nil || len(active) != 1 || active[0].AssetID != replayYesToken {
+		t.Errorf("GetActiveOrders() = %+v, %v", active, err)
+	}
+
+	trades, err := NewTradesAPI(c).GetTrades(ctx, types.TradesRequest{Market: replayCondition})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 || trades[0].Status != types.TradeStatusConfirmed || len(trades[0].MakerOrders) != 1 || trades[0].MakerOrders[0].Side != types.SELL {
+		t.Errorf("GetTrades() = %+v", trades)
+	}
+
+	scoring, err := orders.CheckOrderScoring(ctx, replayOrderID)
+	if err != nil || !scoring.Scoring {
+		t.Errorf("CheckOrderScoring() = %+v, %v", scoring, err)
+	}
+}
+
+func TestReplayOrders(t *testing.T) {
+	c := replayClobClient(t)
+	ctx := context.Background()
+	orders := NewOrdersAPI(c)
+
+	placed, err := orders.PlaceOrder(ctx, types.PostOrder{OrderType: types.GTC, Owner: "key"})
+	if err != nil || !placed.Success || placed.OrderID != replayOrderID || placed.Status != "live" {
+		t.Errorf("PlaceOrder() = %+v, %v", placed, err)
+	}
+
+	// The cassette's second order post was rejected
+	_, err = orders.PlaceOrder(ctx, types.PostOrder{OrderType: types.GTC, Owner: "key"})
+	clobErr, ok := types.AsClobError(err)
+	if !ok || clobErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("rejected PlaceOrder() error = %v", err)
+	}
+
+	canceled, err := orders.CancelOrder(ctx, replayOrderID)
+	if err != nil || len(canceled.Canceled) != 1 || canceled.Canceled[0] != replayOrderID {
+		t.Errorf("CancelOrder() = %+v, %v", canceled, err)
+	}
+
+	all, err := orders.CancelAllOrders(ctx)
+	if err != nil || len(all.Canceled) != 0 || all.NotCanceled["0xc3d4"] != "order already matched" {
+		t.Errorf("CancelAllOrders() = %+v, %v", all, err)
+	}
+}
+
+func TestReplayGamma(t *testing.T) {
+	rec, err := cassette.New("testdata/cassettes/gamma.json", cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gamma := NewGammaAPI(client.NewGammaClient("", client.WithTransport(rec), client.WithRateLimiter(nil), client.WithRetryPolicy(client.NoRetryPolicy())))
+	ctx := context.Background()
+
+	limit := 2
+	markets, err := gamma.GetActiveMarkets(ctx, &limit, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(markets) != 2 || markets[0].ID != "516710" || *markets[0].ConditionID != replayCondition || *markets[0].OrderPriceMinTickSize != 0.01 || markets[0].EndDate.Year() != 2023 {
+		t.Errorf("GetActiveMarkets() = %+v", markets)
+	}
+
+	market, err := gamma.GetMarketByID(ctx, 516710, nil)
+	if err != nil || *market.Slug != "will-it-rain-in-london-on-1-december" || !*market.Active {
+		t.Errorf("GetMarketByID() = %+v, %v", market, err)
+	}
+
+	includeTag := true
+	market, err = gamma.GetMarketBySlug(ctx, "will-it-rain-in-london-on-1-december", &includeTag)
+	if err != nil || len(market.Tags) != 1 || *market.Tags[0].Slug != "weather" {
+		t.Errorf("GetMarketBySlug() = %+v, %v", market, err)
+	}
+
+	tags, err := gamma.GetMarketTags(ctx, 516710)
+	if err != nil || len(tags) != 1 || tags[0].ID != "100" {
+		t.Errorf("GetMarketTags() = %+v, %v", tags, err)
+	}
+
+	events, err := gamma.GetActiveEvents(ctx, &limit, nil)
+	if err != nil || len(events) != 1 || len(events[0].Markets) != 2 {
+		t.Errorf("GetActiveEvents() = %+v, %v", events, err)
+	}
+
+	event, err := gamma.GetEventByID(ctx, 23656, nil, nil)
+	if err != nil || *event.Title != "London weather on 1 December" || *event.Volume != 96423.8 {
+		t.Errorf("GetEventByID() = %+v, %v", event, err)
+	}
+
+	event, err = gamma.GetEventBySlug(ctx, "london-weather-december", nil, nil)
+	if err != nil || event.ID != "23656" {
+		t.Errorf("GetEventBySlug() = %+v, %v", event, err)
+	}
+
+	tagLimit := 1
+	tags, err = gamma.GetTags(ctx, &types.TagFilters{Limit: &tagLimit})
+	if err != nil || len(tags) != 1 || *tags[0].Label != "Weather" {
+		t.Errorf("GetTags() = %+v, %v", tags, err)
+	}
+
+	tag, err := gamma.GetTagByID(ctx, 100, nil)
+	if err != nil || *tag.Slug != "weather" {
+		t.Errorf("GetTagByID() = %+v, %v", tag, err)
+	}
+
+	result, err := gamma.Search(ctx, &types.SearchFilters{Query: "london weather"})
+	if err != nil || len(result.Events) != 1 || *result.Tags[0].EventCount != 42 || result.Pagination.TotalResults != 1 {
+		t.Errorf("Search() = %+v, %v", result, err)
+	}
+
+	_, err = gamma.GetMarketByID(ctx, 999999999, nil)
+	if clobErr, ok := types.AsClobError(err); !ok || clobErr.StatusCode != http.StatusNotFound {
+		t.Errorf("GetMarketByID() of a missing market error = %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// get sends a request through rec and returns the response body
+func get(t *testing.T, rec *Recorder, method, url, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("POLY_API_KEY", "key")
+
+	resp, err := (&http.Client{Transport: rec}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/book":
+			w.Write([]byte(`{"asset_id":"` + r.URL.Query().Get("token_id") + `"}`))
+		case "/auth/derive-api-key":
+			w.Write([]byte(`{"apiKey":"key","secret":"secret","passphrase":"passphrase"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "clob.json")
+	rec, err := New(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Mode() != ModeRecord {
+		t.Fatalf("mode = %d without a cassette file, want ModeRecord", rec.Mode())
+	}
+
+	get(t, rec, http.MethodGet, server.URL+"/book?token_id=1&side=BUY", "")
+	get(t, rec, http.MethodGet, server.URL+"/book?token_id=2", "")
+	if _, body := get(t, rec, http.MethodGet, server.URL+"/auth/derive-api-key", ""); !strings.Contains(body, `"secret":"secret"`) {
+		t.Errorf("recording changed the response to %s", body)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{`"secret"`, `"passphrase"`, `"key"`} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %s:\n%s", secret, data)
+		}
+	}
+
+	replay, err := New(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.Mode() != ModeReplay {
+		t.Fatalf("mode = %d with a cassette file, want ModeReplay", replay.Mode())
+	}
+
+	server.Close()
+	recorded := requests
+
+	// Query parameters match regardless of their order and the host is ignored
+	if status, body := get(t, replay, http.MethodGet, "http://mirror.invalid/book?side=BUY&token_id=1", ""); status != http.StatusOK || body != `{"asset_id":"1"}` {
+		t.Errorf("replayed %d %s", status, body)
+	}
+	if _, body := get(t, replay, http.MethodGet, server.URL+"/book?token_id=2", ""); body != `{"asset_id":"2"}` {
+		t.Errorf("replayed %s", body)
+	}
+	if _, body := get(t, replay, http.MethodGet, server.URL+"/auth/derive-api-key", ""); body != `{"apiKey":"REDACTED","passphrase":"REDACTED","secret":"REDACTED"}` {
+		t.Errorf("replayed %s", body)
+	}
+	if requests != recorded {
+		t.Errorf("replay reached the server")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/book?token_id=3", nil)
+	if _, err := replay.RoundTrip(req); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("unrecorded request error = %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestReplayOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := `{"interactions":[
+		{"request":{"method":"POST","url":"https://clob.example/books","body":"[{\"token_id\":\"1\"}]"},"response":{"status_code":200,"body":"first"}},
+		{"request":{"method":"POST","url":"https://clob.example/books","body":"[{\"token_id\":\"2\"}]"},"response":{"status_code":200,"body":"second"}}
+	]}`
+	if err := os.WriteFile(path, []byte(cassette), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Matching interactions are served in order, the last one repeatedly
+	var bodies []string
+	for i := 0; i < 3; i++ {
+		_, body := get(t, rec, http.MethodPost, "https://clob.example/books", `[{"token_id":"1"}]`)
+		bodies = append(bodies, body)
+	}
+	if strings.Join(bodies, ",") != "first,second,second" {
+		t.Errorf("replayed %v, want first,second,second", bodies)
+	}
+
+	rec, err = New(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.SetMatcher(BodyMatcher(NewRedactor()))
+	if _, body := get(t, rec, http.MethodPost, "https://clob.example/books", `[{"token_id":"2"}]`); body != "second" {
+		t.Errorf("BodyMatcher replayed %s, want second", body)
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,128 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces secret values in recorded interactions
+const Redacted = "REDACTED"
+
+// Redactor removes secrets from recorded headers and JSON bodies
+type Redactor struct {
+	headers map[string]bool
+	fields  map[string]bool
+}
+
+// NewRedactor creates a redactor for the authentication headers and credential fields of the
+// CLOB API: signatures, API keys, secrets and passphrases
+func NewRedactor() *Redactor {
+	r := &Redactor{
+		headers: make(map[string]bool),
+		fields:  make(map[string]bool),
+	}
+
+	r.AddHeaders("POLY_SIGNATURE", "POLY_PASSPHRASE", "POLY_API_KEY", "Authorization", "Cookie", "Set-Cookie")
+	r.AddFields("secret", "passphrase", "apiKey", "apiKeys", "owner", "signature")
+	return r
+}
+
+// AddHeaders adds headers to redact, matched case-insensitively
+func (r *Redactor) AddHeaders(names ...string) {
+	for _, name := range names {
+		r.headers[strings.ToLower(name)] = true
+	}
+}
+
+// AddFields adds JSON object fields to redact at any depth, matched case-insensitively
+func (r *Redactor) AddFields(names ...string) {
+	for _, name := range names {
+		r.fields[strings.ToLower(name)] = true
+	}
+}
+
+// Header returns a copy of header with secret values redacted
+func (r *Redactor) Header(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	redacted := header.Clone()
+	for key := range redacted {
+		if r.headers[strings.ToLower(key)] {
+			redacted[key] = []string{Redacted}
+		}
+	}
+	return redacted
+}
+
+// Body returns body with secret fields redacted; bodies that are not JSON are returned unchanged
+func (r *Redactor) Body(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+	// Text after the first JSON value means the body is not JSON
+	if _, err := decoder.Token(); err != io.EOF {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(r.redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+// redactValue redacts secret fields in a decoded JSON value
+func (r *Redactor) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if r.fields[strings.ToLower(key)] {
+				v[key] = redactAll(field)
+			} else {
+				v[key] = r.redactValue(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.redactValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// redactAll replaces every value in a decoded JSON value, keeping arrays and objects so the
+// redacted body still unmarshals into the same types
+func redactAll(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			v[key] = redactAll(field)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactAll(item)
+		}
+		return v
+	case nil:
+		return nil
+	default:
+		return Redacted
+	}
+}
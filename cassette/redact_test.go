@@ -0,0 +1,88 @@
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRedactorHeader(t *testing.T) {
+	header := http.Header{
+		"Poly_signature": {"sig"},
+		"Poly_api_key":   {"key"},
+		"Poly_address":   {"0xabc"},
+		"Authorization":  {"Bearer token"},
+		"Content-Type":   {"application/json"},
+	}
+
+	redacted := NewRedactor().Header(header)
+
+	want := map[string]string{
+		"Poly_signature": Redacted,
+		"Poly_api_key":   Redacted,
+		"Poly_address":   "0xabc",
+		"Authorization":  Redacted,
+		"Content-Type":   "application/json",
+	}
+	for key, value := range want {
+		if got := redacted.Get(key); got != value {
+			t.Errorf("header %s = %q, want %q", key, got, value)
+		}
+	}
+	if header.Get("Poly_signature") != "sig" {
+		t.Error("Header modified its argument")
+	}
+	if NewRedactor().Header(nil) != nil {
+		t.Error("Header(nil) != nil")
+	}
+}
+
+func TestRedactorBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"credentials", `{"apiKey":"k","secret":"s","passphrase":"p"}`, `{"apiKey":"REDACTED","passphrase":"REDACTED","secret":"REDACTED"}`},
+		{"nested fields", `{"order":{"salt":1,"signature":"0xsig"},"owner":"k","orderType":"GTC"}`, `{"order":{"salt":1,"signature":"REDACTED"},"orderType":"GTC","owner":"REDACTED"}`},
+		{"field case", `{"ApiKey":"k"}`, `{"ApiKey":"REDACTED"}`},
+		{"list keeps its shape", `{"apiKeys":["k1","k2"]}`, `{"apiKeys":["REDACTED","REDACTED"]}`},
+		{"objects in a list", `[{"owner":"k","price":"0.5"},{"owner":null}]`, `[{"owner":"REDACTED","price":"0.5"},{"owner":null}]`},
+		{"large numbers unchanged", `{"salt":123456789012345678901234567890}`, `{"salt":123456789012345678901234567890}`},
+		{"not json", `1700000000 plain`, `1700000000 plain`},
+		{"empty", ``, ``},
+	}
+
+	redactor := NewRedactor()
+	for _, tt := range tests {
+		if got := redactor.Body([]byte(tt.body)); got != tt.want {
+			t.Errorf("%s: Body() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRedactorRedactedListUnmarshals(t *testing.T) {
+	var response struct {
+		APIKeys []string `json:"apiKeys"`
+	}
+	body := NewRedactor().Body([]byte(`{"apiKeys":["k1","k2"]}`))
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("redacted body %s does not unmarshal: %v", body, err)
+	}
+	if len(response.APIKeys) != 2 {
+		t.Errorf("apiKeys = %v, want two redacted keys", response.APIKeys)
+	}
+}
+
+func TestRedactorAddHeadersAndFields(t *testing.T) {
+	redactor := NewRedactor()
+	redactor.AddHeaders("X-Session")
+	redactor.AddFields("funder")
+
+	if got := redactor.Header(http.Header{"X-Session": {"s"}}).Get("X-Session"); got != Redacted {
+		t.Errorf("custom header = %q, want %q", got, Redacted)
+	}
+	if got := redactor.Body([]byte(`{"Funder":"0xabc"}`)); got != `{"Funder":"REDACTED"}` {
+		t.Errorf("custom field body = %s", got)
+	}
+}
@@ -1,10 +1,13 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/lajosdeme/polymarket-go-api/crypto"
 	"github.com/lajosdeme/polymarket-go-api/types"
 )
@@ -21,111 +24,374 @@ const (
 	AuthLevelL2 AuthLevel = iota
 )
 
-// AuthManager handles authentication for the CLOB API
+// AuthManager handles authentication for the CLOB API.
+// It is safe for concurrent use; credentials can be rotated while requests are in flight.
 type AuthManager struct {
+	mu             sync.RWMutex
 	authLevel      AuthLevel
 	signer         *crypto.EIP712Signer
 	apiCredentials *types.APICredentials
 	address        string
 	signatureType  types.SignatureType
 	funder         string
+	chainConfig    types.ChainConfig
+	clock          Clock
+	listeners      map[uint64]func(types.APICredentials)
+	clearListeners map[uint64]func()
+	nextListener   uint64
 }
 
-// NewAuthManager creates a new authentication manager
+// NewAuthManager creates a new authentication manager for Polygon mainnet
 func NewAuthManager() *AuthManager {
+	return NewAuthManagerWithChainConfig(types.PolygonMainnet)
+}
+
+// NewAuthManagerWithChainConfig creates a new authentication manager for the given chain
+func NewAuthManagerWithChainConfig(chainConfig types.ChainConfig) *AuthManager {
 	return &AuthManager{
-		authLevel: AuthLevelNone,
+		authLevel:      AuthLevelNone,
+		chainConfig:    chainConfig,
+		clock:          systemClock{},
+		listeners:      make(map[uint64]func(types.APICredentials)),
+		clearListeners: make(map[uint64]func()),
 	}
 }
 
-// SetupL1Auth sets up L1 authentication with private key
+// SetupL1Auth sets up L1 authentication with private key.
+// For POLY_PROXY and GNOSIS_SAFE wallets the funder is the proxy wallet holding the funds;
+// for EOA wallets the funder defaults to, and must match, the signer address.
 func (am *AuthManager) SetupL1Auth(privateKeyHex string, signatureType types.SignatureType, funder string) error {
 	if privateKeyHex == "" {
 		return fmt.Errorf("private key cannot be empty")
 	}
 
-	// Create EIP-712 signer
-	signer, err := crypto.NewEIP712Signer(privateKeyHex, 137) // Polygon chain ID
+	signer, err := crypto.NewPrivateKeySigner(privateKeyHex)
+	if err != nil {
+		return fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	return am.SetupL1AuthWithSigner(signer, signatureType, funder)
+}
+
+// SetupL1AuthFromKeystore sets up L1 authentication with a go-ethereum V3 keystore file
+func (am *AuthManager) SetupL1AuthFromKeystore(path, passphrase string, signatureType types.SignatureType, funder string) error {
+	signer, err := crypto.LoadKeystoreSigner(path, passphrase)
 	if err != nil {
 		return fmt.Errorf("failed to create signer: %w", err)
 	}
 
-	am.authLevel = AuthLevelL1
-	am.signer = signer
-	am.address = signer.GetAddress()
+	return am.SetupL1AuthWithSigner(signer, signatureType, funder)
+}
+
+// SetupL1AuthWithSigner sets up L1 authentication with an external Signer,
+// e.g. a crypto.RemoteSigner backed by a KMS
+// API credentials of a previous, different address are cleared and clear listeners notified.
+func (am *AuthManager) SetupL1AuthWithSigner(signer crypto.Signer, signatureType types.SignatureType, funder string) error {
+	if signer == nil {
+		return fmt.Errorf("signer cannot be nil")
+	}
+
+	// Create EIP-712 signer
+	eip712Signer := crypto.NewEIP712SignerWithSigner(signer, am.chainConfig.ChainID)
+
+	address := eip712Signer.GetAddress()
+	funder, err := resolveFunder(address, signatureType, funder)
+	if err != nil {
+		return err
+	}
+
+	am.mu.Lock()
+	// API credentials belong to the address they were created for
+	cleared := am.address != "" && address != am.address && am.apiCredentials != nil
+	if cleared {
+		am.apiCredentials = nil
+	}
+	if am.apiCredentials == nil {
+		am.authLevel = AuthLevelL1
+	}
+	am.signer = eip712Signer
+	am.address = address
 	am.signatureType = signatureType
 	am.funder = funder
+	listeners := am.clearedListeners()
+	am.mu.Unlock()
+
+	if cleared {
+		for _, listener := range listeners {
+			listener()
+		}
+	}
 
 	return nil
 }
 
+// resolveFunder validates the funder address for a signature type
+func resolveFunder(address string, signatureType types.SignatureType, funder string) (string, error) {
+	switch signatureType {
+	case types.EOA:
+		if funder == "" {
+			return address, nil
+		}
+		if !common.IsHexAddress(funder) || common.HexToAddress(funder) != common.HexToAddress(address) {
+			return "", fmt.Errorf("funder must be the signer address for EOA wallets")
+		}
+		return common.HexToAddress(funder).Hex(), nil
+	case types.POLY_PROXY, types.GNOSIS_SAFE:
+		if !common.IsHexAddress(funder) {
+			return "", fmt.Errorf("a valid funder address is required for %s wallets", signatureType)
+		}
+		return common.HexToAddress(funder).Hex(), nil
+	default:
+		return "", fmt.Errorf("unsupported signature type: %d", signatureType)
+	}
+}
+
 // SetupL2Auth sets up L2 authentication with API credentials
 func (am *AuthManager) SetupL2Auth(apiKey, secret, passphrase string) error {
-	if apiKey == "" || secret == "" || passphrase == "" {
+	return am.RotateAPICredentials(types.APICredentials{
+		APIKey:     apiKey,
+		Secret:     secret,
+		Passphrase: passphrase,
+	})
+}
+
+// RotateAPICredentials atomically replaces the API credentials, e.g. after CreateAPIKey.
+// Requests signed after it returns use the new credentials and rotation listeners are notified.
+func (am *AuthManager) RotateAPICredentials(creds types.APICredentials) error {
+	if creds.APIKey == "" || creds.Secret == "" || creds.Passphrase == "" {
 		return fmt.Errorf("API credentials cannot be empty")
 	}
 
+	am.mu.Lock()
+	rotated := am.apiCredentials == nil || *am.apiCredentials != creds
 	am.authLevel = AuthLevelL2
-	am.apiCredentials = &types.APICredentials{
-		APIKey:     apiKey,
-		Secret:     secret,
-		Passphrase: passphrase,
+	am.apiCredentials = &creds
+	listeners := make([]func(types.APICredentials), 0, len(am.listeners))
+	for _, listener := range am.listeners {
+		listeners = append(listeners, listener)
+	}
+	am.mu.Unlock()
+
+	if rotated {
+		for _, listener := range listeners {
+			listener(creds)
+		}
 	}
 
 	return nil
 }
 
-// GetAddress returns the authenticated address
+// ClearAPICredentials removes the API credentials, e.g. after their key was deleted or revoked,
+// and notifies clear listeners. L1 authentication, if set up, is kept.
+func (am *AuthManager) ClearAPICredentials() {
+	am.mu.Lock()
+	cleared := am.apiCredentials != nil
+	am.apiCredentials = nil
+	if am.signer != nil {
+		am.authLevel = AuthLevelL1
+	} else {
+		am.authLevel = AuthLevelNone
+	}
+	listeners := am.clearedListeners()
+	am.mu.Unlock()
+
+	if cleared {
+		for _, listener := range listeners {
+			listener()
+		}
+	}
+}
+
+// OnCredentialsCleared registers a function called whenever the API credentials are removed,
+// by ClearAPICredentials or by switching L1 authentication to another address. It returns a
+// function that unregisters it.
+func (am *AuthManager) OnCredentialsCleared(fn func()) func() {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	id := am.nextListener
+	am.nextListener++
+	am.clearListeners[id] = fn
+
+	return func() {
+		am.mu.Lock()
+		defer am.mu.Unlock()
+
+		delete(am.clearListeners, id)
+	}
+}
+
+// clearedListeners returns the registered clear listeners; callers must hold mu
+func (am *AuthManager) clearedListeners() []func() {
+	listeners := make([]func(), 0, len(am.clearListeners))
+	for _, listener := range am.clearListeners {
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
+
+// OnCredentialsRotated registers a function called with the new credentials whenever the
+// API credentials change. It returns a function that unregisters it.
+func (am *AuthManager) OnCredentialsRotated(fn func(creds types.APICredentials)) func() {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	id := am.nextListener
+	am.nextListener++
+	am.listeners[id] = fn
+
+	return func() {
+		am.mu.Lock()
+		defer am.mu.Unlock()
+
+		delete(am.listeners, id)
+	}
+}
+
+// GetAddress returns the authenticated signer address
 func (am *AuthManager) GetAddress() string {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
 	return am.address
 }
 
 // GetSignatureType returns the signature type
 func (am *AuthManager) GetSignatureType() types.SignatureType {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
 	return am.signatureType
 }
 
-// GetFunder returns the funder address
+// GetFunder returns the funder address, which is the maker of orders
 func (am *AuthManager) GetFunder() string {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
 	return am.funder
 }
 
-// GetAPICredentials returns the API credentials
+// GetChainConfig returns the chain configuration used for signing
+func (am *AuthManager) GetChainConfig() types.ChainConfig {
+	return am.chainConfig
+}
+
+// SetClock sets the clock used for authentication timestamps, e.g. a *ServerClock; nil restores the local clock
+func (am *AuthManager) SetClock(clock Clock) {
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	am.clock = clock
+}
+
+// Now returns the current time of the authentication clock
+func (am *AuthManager) Now() time.Time {
+	am.mu.RLock()
+	clock := am.clock
+	am.mu.RUnlock()
+
+	return clock.Now()
+}
+
+// GetAPICredentials returns a copy of the API credentials, nil if none are set
 func (am *AuthManager) GetAPICredentials() *types.APICredentials {
-	return am.apiCredentials
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	if am.apiCredentials == nil {
+		return nil
+	}
+	creds := *am.apiCredentials
+	return &creds
 }
 
 // GetAuthLevel returns the current authentication level
 func (am *AuthManager) GetAuthLevel() AuthLevel {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
 	return am.authLevel
 }
 
-// SignL1Message signs a message using L1 authentication
-func (am *AuthManager) SignL1Message(timestamp string, nonce uint64) (string, error) {
+// l1Signer returns the L1 signer, or an error if L1 authentication is not set up
+func (am *AuthManager) l1Signer() (*crypto.EIP712Signer, error) {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
 	if am.authLevel < AuthLevelL1 {
-		return "", fmt.Errorf("L1 authentication required")
+		return nil, fmt.Errorf("L1 authentication required")
 	}
 
 	if am.signer == nil {
-		return "", fmt.Errorf("signer not initialized")
+		return nil, fmt.Errorf("signer not initialized")
 	}
 
-	return am.signer.SignClobAuth(timestamp, nonce)
+	return am.signer, nil
 }
 
-// GenerateL1Headers generates L1 authentication headers
-func (am *AuthManager) GenerateL1Headers(timestamp string, nonce uint64) (map[string]string, error) {
-	if am.authLevel < AuthLevelL1 {
-		return nil, fmt.Errorf("L1 authentication required")
+// SignL1Message signs a message using L1 authentication
+func (am *AuthManager) SignL1Message(ctx context.Context, timestamp string, nonce uint64) (string, error) {
+	signer, err := am.l1Signer()
+	if err != nil {
+		return "", err
 	}
 
-	signature, err := am.SignL1Message(timestamp, nonce)
+	return signer.SignClobAuth(ctx, timestamp, nonce)
+}
+
+// SignOrder signs an order with the L1 signer against the exchange contract of the
+// configured chain, using the neg-risk exchange for neg-risk markets
+func (am *AuthManager) SignOrder(ctx context.Context, order *types.Order, negRisk bool) error {
+	signer, err := am.l1Signer()
+	if err != nil {
+		return err
+	}
+
+	signature, err := signer.SignOrder(ctx, order, am.chainConfig.ExchangeAddress(negRisk))
+	if err != nil {
+		return err
+	}
+
+	order.Signature = signature
+	return nil
+}
+
+// OrderHash computes the hash of an order on the configured chain, matching the order ID
+// assigned by the CLOB, so orders can be tracked before the server answers
+func (am *AuthManager) OrderHash(order *types.Order, negRisk bool) (string, error) {
+	hash, err := crypto.OrderHash(order, am.chainConfig.ChainID, am.chainConfig.ExchangeAddress(negRisk))
+	if err != nil {
+		return "", err
+	}
+
+	return hash.Hex(), nil
+}
+
+// VerifyOrder checks that an order was signed by its Signer on the configured chain
+func (am *AuthManager) VerifyOrder(order *types.Order, negRisk bool) error {
+	return crypto.VerifyOrderSignature(order, am.chainConfig.ChainID, am.chainConfig.ExchangeAddress(negRisk))
+}
+
+// GenerateL1Headers generates L1 authentication headers.
+// POLY_ADDRESS is always the signer address, also for proxy and Safe wallets.
+func (am *AuthManager) GenerateL1Headers(ctx context.Context, timestamp string, nonce uint64) (map[string]string, error) {
+	signer, err := am.l1Signer()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.SignClobAuth(ctx, timestamp, nonce)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign message: %w", err)
 	}
 
 	headers := map[string]string{
-		"POLY_ADDRESS":   am.address,
+		"POLY_ADDRESS":   signer.GetAddress(),
 		"POLY_SIGNATURE": signature,
 		"POLY_TIMESTAMP": timestamp,
 		"POLY_NONCE":     strconv.FormatUint(nonce, 10),
@@ -134,30 +400,52 @@ func (am *AuthManager) GenerateL1Headers(timestamp string, nonce uint64) (map[st
 	return headers, nil
 }
 
-// GenerateL2Headers generates L2 authentication headers
+// GenerateL2Headers generates L2 authentication headers.
+// POLY_ADDRESS is always the signer address, also for proxy and Safe wallets.
 func (am *AuthManager) GenerateL2Headers(method, path, body string) (map[string]string, error) {
-	if am.authLevel < AuthLevelL2 {
+	// Snapshot the credentials so a concurrent rotation cannot mix old and new values
+	am.mu.RLock()
+	authLevel, creds, address, clock := am.authLevel, am.apiCredentials, am.address, am.clock
+	am.mu.RUnlock()
+
+	if authLevel < AuthLevelL2 {
 		return nil, fmt.Errorf("L2 authentication required")
 	}
 
-	if am.apiCredentials == nil {
+	if creds == nil {
 		return nil, fmt.Errorf("API credentials not initialized")
 	}
 
-	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	return l2Headers(*creds, address, clock, method, path, body)
+}
+
+// GenerateL2HeadersWith generates L2 authentication headers signed with creds instead of the
+// installed credentials, e.g. to check credentials before installing them
+func (am *AuthManager) GenerateL2HeadersWith(creds types.APICredentials, method, path, body string) (map[string]string, error) {
+	am.mu.RLock()
+	address, clock := am.address, am.clock
+	am.mu.RUnlock()
+
+	return l2Headers(creds, address, clock, method, path, body)
+}
+
+// l2Headers generates the L2 authentication headers of a request signed with creds
+func l2Headers(creds types.APICredentials, address string, clock Clock, method, path, body string) (map[string]string, error) {
+	now := clock.Now().Unix()
+	timestamp := strconv.FormatInt(now, 10)
 
 	// Generate HMAC signature
-	signature, err := crypto.SignRequest(am.apiCredentials.Secret, method, path, body, time.Now().Unix())
+	signature, err := crypto.SignRequest(creds.Secret, method, path, body, now)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign request: %w", err)
 	}
 
 	headers := map[string]string{
-		"POLY_ADDRESS":    am.address,
+		"POLY_ADDRESS":    address,
 		"POLY_SIGNATURE":  signature,
 		"POLY_TIMESTAMP":  timestamp,
-		"POLY_API_KEY":    am.apiCredentials.APIKey,
-		"POLY_PASSPHRASE": am.apiCredentials.Passphrase,
+		"POLY_API_KEY":    creds.APIKey,
+		"POLY_PASSPHRASE": creds.Passphrase,
 	}
 
 	return headers, nil
@@ -165,21 +453,24 @@ func (am *AuthManager) GenerateL2Headers(method, path, body string) (map[string]
 
 // IsAuthenticated returns true if authenticated at any level
 func (am *AuthManager) IsAuthenticated() bool {
-	return am.authLevel > AuthLevelNone
+	return am.GetAuthLevel() > AuthLevelNone
 }
 
 // HasL1Auth returns true if L1 authentication is set up
 func (am *AuthManager) HasL1Auth() bool {
-	return am.authLevel >= AuthLevelL1
+	return am.GetAuthLevel() >= AuthLevelL1
 }
 
 // HasL2Auth returns true if L2 authentication is set up
 func (am *AuthManager) HasL2Auth() bool {
-	return am.authLevel >= AuthLevelL2
+	return am.GetAuthLevel() >= AuthLevelL2
 }
 
 // Clear clears all authentication
 func (am *AuthManager) Clear() {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
 	am.authLevel = AuthLevelNone
 	am.signer = nil
 	am.apiCredentials = nil
@@ -0,0 +1,290 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState string
+
+const (
+	// CircuitClosed - Requests pass through
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen - Requests fail fast with types.ErrCircuitOpen
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen - One probe request is let through to test recovery
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// BreakerConfig configures a circuit breaker
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive server errors or timeouts that opens the circuit
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a probe is let through
+	OpenTimeout time.Duration
+}
+
+// DefaultBreakerConfig returns the circuit breaker configuration used by NewCircuitBreaker
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+// CircuitBreaker stops sending requests to an endpoint group of a server after repeated server
+// errors or timeouts and fails them fast until a probe succeeds. Every base URL has its own
+// circuits, so read-only requests skip an unhealthy primary and go straight to its alternates.
+// It is safe for concurrent use and can be shared by several clients.
+type CircuitBreaker struct {
+	mu            sync.Mutex
+	config        BreakerConfig
+	circuits      map[circuitKey]*circuit
+	onStateChange func(baseURL string, group EndpointGroup, from, to CircuitState)
+}
+
+// circuitKey identifies the circuit of an endpoint group on one server
+type circuitKey struct {
+	baseURL string
+	group   EndpointGroup
+}
+
+// circuit is the breaker state of one endpoint group on one server
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a circuit breaker; zero config fields use DefaultBreakerConfig
+func NewCircuitBreaker(config BreakerConfig) *CircuitBreaker {
+	defaults := DefaultBreakerConfig()
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaults.FailureThreshold
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = defaults.OpenTimeout
+	}
+
+	return &CircuitBreaker{
+		config:   config,
+		circuits: make(map[circuitKey]*circuit),
+	}
+}
+
+// OnStateChange sets a function called on every state change, e.g. to log or alert.
+// It is called with the breaker locked and must not call back into it.
+func (b *CircuitBreaker) OnStateChange(fn func(baseURL string, group EndpointGroup, from, to CircuitState)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.onStateChange = fn
+}
+
+// State returns the state of the circuit of an endpoint group on the server at baseURL
+func (b *CircuitBreaker) State(baseURL string, group EndpointGroup) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(circuitKey{baseURL, group})
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= b.config.OpenTimeout {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// Reset closes the circuit of an endpoint group on the server at baseURL
+func (b *CircuitBreaker) Reset(baseURL string, group EndpointGroup) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := circuitKey{baseURL, group}
+	c := b.circuit(key)
+	c.failures = 0
+	b.setState(key, c, CircuitClosed)
+}
+
+// rejects reports whether the circuit of key fails requests fast right now and how long it
+// stays open, without taking the probe of a half-open circuit
+func (b *CircuitBreaker) rejects(key circuitKey) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(key)
+	switch c.state {
+	case CircuitOpen:
+		remaining := b.config.OpenTimeout - time.Since(c.openedAt)
+		return remaining, remaining > 0
+	case CircuitHalfOpen:
+		return 0, c.probing
+	}
+	return 0, false
+}
+
+// allow reports whether a request may be sent through the circuit of key, or how long the
+// circuit stays open. probe is true for the single request let through a half-open circuit.
+func (b *CircuitBreaker) allow(key circuitKey) (ok, probe bool, remaining time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(key)
+	switch c.state {
+	case CircuitOpen:
+		remaining = b.config.OpenTimeout - time.Since(c.openedAt)
+		if remaining > 0 {
+			return false, false, remaining
+		}
+		b.setState(key, c, CircuitHalfOpen)
+		fallthrough
+	case CircuitHalfOpen:
+		if c.probing {
+			return false, false, 0
+		}
+		c.probing = true
+		return true, true, 0
+	}
+	return true, false, 0
+}
+
+// done records the outcome of a request let through by allow
+func (b *CircuitBreaker) done(key circuitKey, probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(key)
+	failure := isServerFailure(err)
+	// A canceled request says nothing about the server
+	canceled := !failure && errors.Is(err, context.Canceled)
+
+	switch {
+	case probe:
+		c.probing = false
+		if failure {
+			c.openedAt = time.Now()
+			b.setState(key, c, CircuitOpen)
+		} else if !canceled {
+			c.failures = 0
+			b.setState(key, c, CircuitClosed)
+		}
+	case c.state != CircuitClosed:
+		// Sent before the circuit opened
+	case failure:
+		c.failures++
+		if c.failures >= b.config.FailureThreshold {
+			c.openedAt = time.Now()
+			b.setState(key, c, CircuitOpen)
+		}
+	case !canceled:
+		c.failures = 0
+	}
+}
+
+// circuit returns the circuit of key, creating it closed; b.mu must be held
+func (b *CircuitBreaker) circuit(key circuitKey) *circuit {
+	c := b.circuits[key]
+	if c == nil {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[key] = c
+	}
+	return c
+}
+
+// setState changes the state of a circuit; b.mu must be held
+func (b *CircuitBreaker) setState(key circuitKey, c *circuit, state CircuitState) {
+	if c.state == state {
+		return
+	}
+	from := c.state
+	c.state = state
+	if b.onStateChange != nil {
+		b.onStateChange(key.baseURL, key.group, from, state)
+	}
+}
+
+// send sends a request to the server at baseURL with handler unless its circuit is open,
+// and records the outcome. A nil breaker sends every request.
+func (b *CircuitBreaker) send(ctx context.Context, baseURL string, req *Request, handler Handler) ([]byte, error) {
+	if b == nil {
+		return handler(ctx, req)
+	}
+
+	key := circuitKey{baseURL, req.Group}
+	ok, probe, remaining := b.allow(key)
+	if !ok {
+		return nil, circuitOpenError(req, remaining)
+	}
+
+	body, err := handler(ctx, req)
+	b.done(key, probe, err)
+
+	return body, err
+}
+
+// isServerFailure reports whether an error indicates an unhealthy server:
+// a 5xx response, a timeout or another failure to reach the server
+func isServerFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if clobErr, ok := types.AsClobError(err); ok {
+		return clobErr.StatusCode >= 500
+	}
+	var tErr *transportError
+	return errors.As(err, &tErr) && !errors.Is(err, context.Canceled)
+}
+
+// isCircuitOpen reports whether a request failed fast on an open circuit
+func isCircuitOpen(err error) bool {
+	clobErr, ok := types.AsClobError(err)
+	return ok && clobErr.Code == types.ErrCircuitOpen
+}
+
+// circuitOpenError returns the error of a request failed fast on a circuit open for remaining
+func circuitOpenError(req *Request, remaining time.Duration) *types.ClobError {
+	return &types.ClobError{
+		Code:       types.ErrCircuitOpen,
+		Message:    fmt.Sprintf("Circuit breaker open for %s endpoints", req.Group),
+		Method:     req.Method,
+		Path:       req.Path,
+		RetryAfter: remaining,
+	}
+}
+
+// CircuitBreakerMiddleware fails requests fast with a *types.ClobError of code types.ErrCircuitOpen
+// while their endpoint group's circuit is open on every server they may be sent to: baseURL, and
+// the alternates for unauthenticated read-only requests. The circuits record outcomes in the transport of the
+// clients, which skips servers whose circuit is open.
+func CircuitBreakerMiddleware(breaker *CircuitBreaker, baseURL string, alternates ...string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			if breaker == nil {
+				return next(ctx, req)
+			}
+
+			targets := []string{baseURL}
+			if mayFailOver(req) {
+				targets = append(targets, alternates...)
+			}
+
+			var retryAfter time.Duration
+			for i, target := range targets {
+				remaining, open := breaker.rejects(circuitKey{target, req.Group})
+				if !open {
+					return next(ctx, req)
+				}
+				if i == 0 || remaining < retryAfter {
+					retryAfter = remaining
+				}
+			}
+
+			return nil, circuitOpenError(req, retryAfter)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	breaker := NewCircuitBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond})
+	key := circuitKey{"https://clob.example", GroupMarketData}
+
+	var transitions []CircuitState
+	breaker.OnStateChange(func(baseURL string, group EndpointGroup, from, to CircuitState) {
+		transitions = append(transitions, to)
+	})
+
+	serverErr := &types.ClobError{Code: types.ErrInternalError, StatusCode: http.StatusBadGateway}
+	notFound := &types.ClobError{Code: types.ErrNotFound, StatusCode: http.StatusNotFound}
+
+	record := func(err error) {
+		ok, probe, _ := breaker.allow(key)
+		if !ok {
+			t.Fatal("request rejected by a closed circuit")
+		}
+		breaker.done(key, probe, err)
+	}
+
+	// Client errors and canceled requests do not count as failures
+	record(serverErr)
+	record(notFound)
+	record(serverErr)
+	record(&transportError{op: "perform request", err: context.Canceled})
+	if state := breaker.State(key.baseURL, key.group); state != CircuitClosed {
+		t.Fatalf("state = %s, want closed", state)
+	}
+
+	record(serverErr)
+	if state := breaker.State(key.baseURL, key.group); state != CircuitOpen {
+		t.Fatalf("state = %s after two consecutive failures, want open", state)
+	}
+	if ok, _, remaining := breaker.allow(key); ok || remaining <= 0 {
+		t.Fatalf("allow() on open circuit = %v, %s", ok, remaining)
+	}
+
+	// Other servers and groups are unaffected
+	if state := breaker.State("https://mirror.example", key.group); state != CircuitClosed {
+		t.Errorf("alternate state = %s, want closed", state)
+	}
+	if state := breaker.State(key.baseURL, GroupOrders); state != CircuitClosed {
+		t.Errorf("orders state = %s, want closed", state)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	ok, probe, _ := breaker.allow(key)
+	if !ok || !probe {
+		t.Fatalf("allow() after open timeout = %v, probe %v, want the probe", ok, probe)
+	}
+	if ok, _, _ := breaker.allow(key); ok {
+		t.Fatal("second request let through a half-open circuit")
+	}
+	breaker.done(key, true, serverErr)
+	if state := breaker.State(key.baseURL, key.group); state != CircuitOpen {
+		t.Fatalf("state = %s after failed probe, want open", state)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	ok, probe, _ = breaker.allow(key)
+	if !ok || !probe {
+		t.Fatal("probe not let through")
+	}
+	breaker.done(key, true, nil)
+	if state := breaker.State(key.baseURL, key.group); state != CircuitClosed {
+		t.Fatalf("state = %s after successful probe, want closed", state)
+	}
+
+	want := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("transitions = %v, want %v", transitions, want)
+		}
+	}
+}
+
+func TestCircuitBreakerFailsFast(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(BreakerConfig{FailureThreshold: 3, OpenTimeout: time.Minute})
+	c := NewClobClient(server.URL, WithCircuitBreaker(breaker), WithRetryPolicy(NoRetryPolicy()), WithRateLimiter(nil))
+
+	for i := 0; i < 5; i++ {
+		c.DoGet(context.Background(), "/book", false, nil)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("%d requests reached the server, want 3", got)
+	}
+
+	_, err := c.DoGet(context.Background(), "/book", false, nil)
+	clobErr, ok := types.AsClobError(err)
+	if !ok || clobErr.Code != types.ErrCircuitOpen || clobErr.RetryAfter <= 0 {
+		t.Fatalf("error = %v, want %s with RetryAfter", err, types.ErrCircuitOpen)
+	}
+}
+
+func TestCircuitBreakerFailover(t *testing.T) {
+	var primaryRequests, alternateRequests atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryRequests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	alternate := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alternateRequests.Add(1)
+		w.Write([]byte(`{"alternate":true}`))
+	}))
+	defer alternate.Close()
+
+	breaker := NewCircuitBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	c := NewClobClient(primary.URL,
+		WithCircuitBreaker(breaker),
+		WithFailoverURLs(alternate.URL),
+		WithRetryPolicy(NoRetryPolicy()),
+		WithRateLimiter(nil),
+	)
+
+	for i := 0; i < 5; i++ {
+		body, err := c.DoGet(context.Background(), "/book", false, nil)
+		if err != nil || string(body) != `{"alternate":true}` {
+			t.Fatalf("read %d = %s, %v, want the alternate's response", i, body, err)
+		}
+	}
+
+	if got := primaryRequests.Load(); got != 2 {
+		t.Errorf("%d reads reached the degraded primary, want 2 before its circuit opened", got)
+	}
+	if got := alternateRequests.Load(); got != 5 {
+		t.Errorf("%d reads reached the alternate, want 5", got)
+	}
+	if state := breaker.State(primary.URL, GroupMarketData); state != CircuitOpen {
+		t.Errorf("primary state = %s, want open", state)
+	}
+	if state := breaker.State(alternate.URL, GroupMarketData); state != CircuitClosed {
+		t.Errorf("alternate state = %s, want closed", state)
+	}
+
+	// Writes never fail over, so they fail fast while the primary's circuit is open
+	for i := 0; i < 2; i++ {
+		if _, err := c.DoGet(context.Background(), "/data/orders", false, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.DoRequest(context.Background(), http.MethodPost, "/auth/api-key", nil, false); !isCircuitOpen(err) {
+		t.Fatalf("write error = %v, want %s", err, types.ErrCircuitOpen)
+	}
+	if got := primaryRequests.Load(); got != 4 {
+		t.Errorf("%d requests reached the degraded primary, want 4", got)
+	}
+}
+
+func TestFailoverSkipsAuthenticatedRequests(t *testing.T) {
+	var alternateRequests atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	alternate := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alternateRequests.Add(1)
+		w.Write([]byte(`{}`))
+	}))
+	defer alternate.Close()
+
+	c := NewClobClient(primary.URL, WithFailoverURLs(alternate.URL), WithRetryPolicy(NoRetryPolicy()), WithRateLimiter(nil))
+	if err := c.SetupL1Auth("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", types.EOA, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetupL2Auth("key", "c2VjcmV0", "passphrase"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.DoGet(context.Background(), "/data/orders", true, nil); err == nil {
+		t.Error("L2 authenticated GET succeeded on an alternate")
+	}
+	if _, err := c.DoGetWithL1Auth(context.Background(), "/auth/derive-api-key", 0, 1700000000, nil); err == nil {
+		t.Error("L1 authenticated GET succeeded on an alternate")
+	}
+	if got := alternateRequests.Load(); got != 0 {
+		t.Fatalf("%d authenticated requests reached the alternate, want 0", got)
+	}
+
+	if _, err := c.DoGet(context.Background(), "/book", false, nil); err != nil {
+		t.Errorf("public GET did not fail over: %v", err)
+	}
+}
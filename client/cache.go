@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache lookup results reported to Metrics.ObserveCacheLookup
+const (
+	CacheHit    = "hit"
+	CacheMiss   = "miss"
+	CacheShared = "shared"
+)
+
+// maxCacheEntries bounds the number of cached responses
+const maxCacheEntries = 10000
+
+// cacheFetchTimeout bounds a shared fetch, which is not canceled with the caller that started it
+const cacheFetchTimeout = time.Minute
+
+// DefaultCacheTTLs returns cache lifetimes for frequently polled public endpoints, keyed by
+// endpoint label (see EndpointLabel)
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"/book":                500 * time.Millisecond,
+		"/price":               500 * time.Millisecond,
+		"/midpoint":            500 * time.Millisecond,
+		"/prices":              500 * time.Millisecond,
+		"/tick-size":           time.Minute,
+		"/neg-risk":            time.Minute,
+		"/markets/{id}":        30 * time.Second,
+		"/markets/slug/{slug}": 30 * time.Second,
+		"/events/{id}":         30 * time.Second,
+		"/events/slug/{slug}":  30 * time.Second,
+		"/tags":                5 * time.Minute,
+		"/tags/{id}":           5 * time.Minute,
+		"/tags/slug/{slug}":    5 * time.Minute,
+	}
+}
+
+// ResponseCache caches responses of public GET requests with per-endpoint lifetimes and
+// coalesces identical requests in flight, so concurrent callers share one request.
+// It is safe for concurrent use and can be shared by several clients.
+type ResponseCache struct {
+	mu      sync.Mutex
+	ttls    map[string]time.Duration
+	entries map[string]cacheEntry
+	flights map[string]*flight
+}
+
+// cacheEntry is a cached response body
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// flight is a request in flight whose result is shared by identical requests
+type flight struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// NewResponseCache creates a cache with lifetimes keyed by endpoint label, e.g. "/book" or
+// "/markets/slug/{slug}". Endpoints with a zero lifetime are coalesced but not cached;
+// endpoints missing from ttls are neither.
+func NewResponseCache(ttls map[string]time.Duration) *ResponseCache {
+	c := &ResponseCache{
+		ttls:    make(map[string]time.Duration),
+		entries: make(map[string]cacheEntry),
+		flights: make(map[string]*flight),
+	}
+	for endpoint, ttl := range ttls {
+		c.ttls[endpoint] = ttl
+	}
+	return c
+}
+
+// SetTTL sets the cache lifetime of an endpoint; zero only coalesces requests to it
+func (c *ResponseCache) SetTTL(endpoint string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttls[endpoint] = ttl
+}
+
+// Remove stops caching and coalescing requests to an endpoint
+func (c *ResponseCache) Remove(endpoint string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.ttls, endpoint)
+}
+
+// handles reports whether requests to an endpoint go through the cache
+func (c *ResponseCache) handles(endpoint string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.ttls[endpoint]
+	return ok
+}
+
+// Clear removes all cached responses
+func (c *ResponseCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]cacheEntry)
+}
+
+// do returns the cached response for key or runs fetch, sharing its result with identical
+// requests that arrive while it is in flight. fetch runs detached from ctx, so a caller giving
+// up only ends its own wait and not the wait of the others.
+func (c *ResponseCache) do(ctx context.Context, key, endpoint string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, string, error) {
+	c.mu.Lock()
+	now := time.Now()
+	if entry, ok := c.entries[key]; ok {
+		if now.Before(entry.expires) {
+			c.mu.Unlock()
+			return entry.body, CacheHit, nil
+		}
+		delete(c.entries, key)
+	}
+
+	if f, ok := c.flights[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.body, CacheShared, f.err
+		case <-ctx.Done():
+			return nil, CacheShared, ctx.Err()
+		}
+	}
+
+	f := &flight{done: make(chan struct{})}
+	c.flights[key] = f
+	ttl := c.ttls[endpoint]
+	c.mu.Unlock()
+
+	go func() {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheFetchTimeout)
+		defer cancel()
+
+		body, err := fetch(fetchCtx)
+
+		c.mu.Lock()
+		f.body, f.err = body, err
+		delete(c.flights, key)
+		if err == nil && ttl > 0 {
+			c.store(key, cacheEntry{body: body, expires: time.Now().Add(ttl)})
+		}
+		c.mu.Unlock()
+		close(f.done)
+	}()
+
+	select {
+	case <-f.done:
+		return f.body, CacheMiss, f.err
+	case <-ctx.Done():
+		return nil, CacheMiss, ctx.Err()
+	}
+}
+
+// store adds an entry, dropping expired entries when the cache is full; c.mu must be held
+func (c *ResponseCache) store(key string, entry cacheEntry) {
+	if len(c.entries) >= maxCacheEntries {
+		now := time.Now()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCacheEntries {
+			return
+		}
+	}
+	c.entries[key] = entry
+}
+
+// cacheKey identifies a request by base URL, path and sorted query parameters
+func cacheKey(baseURL string, req *Request) string {
+	keys := make([]string, 0, len(req.Query))
+	for key := range req.Query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(baseURL)
+	b.WriteString(req.Path)
+	for i, key := range keys {
+		if i == 0 {
+			b.WriteByte('?')
+		} else {
+			b.WriteByte('&')
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(req.Query[key])
+	}
+	return b.String()
+}
+
+// cacheMiddleware serves public GET requests to configured endpoints from cache and coalesces
+// identical ones in flight. Authenticated requests and other methods pass through.
+func cacheMiddleware(cache *ResponseCache, baseURL string, metrics Metrics) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			if cache == nil || req.Method != http.MethodGet || req.Auth != AuthLevelNone {
+				return next(ctx, req)
+			}
+
+			endpoint := EndpointLabel(req.Path)
+			if !cache.handles(endpoint) {
+				return next(ctx, req)
+			}
+
+			body, result, err := cache.do(ctx, cacheKey(baseURL, req), endpoint, func(ctx context.Context) ([]byte, error) {
+				return next(ctx, req)
+			})
+			metrics.ObserveCacheLookup(endpoint, result)
+
+			return body, err
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheHitAndExpiry(t *testing.T) {
+	cache := NewResponseCache(map[string]time.Duration{"/book": 50 * time.Millisecond})
+
+	var fetches int
+	fetch := func(context.Context) ([]byte, error) {
+		fetches++
+		return []byte("book"), nil
+	}
+
+	results := make([]string, 0, 3)
+	for _, wait := range []time.Duration{0, 0, 60 * time.Millisecond} {
+		time.Sleep(wait)
+		body, result, err := cache.do(context.Background(), "k", "/book", fetch)
+		if err != nil || string(body) != "book" {
+			t.Fatalf("do() = %q, %v", body, err)
+		}
+		results = append(results, result)
+	}
+
+	want := []string{CacheMiss, CacheHit, CacheMiss}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("lookup %d = %s, want %s", i, results[i], want[i])
+		}
+	}
+	if fetches != 2 {
+		t.Errorf("%d fetches, want 2", fetches)
+	}
+}
+
+func TestResponseCacheErrorsNotCached(t *testing.T) {
+	cache := NewResponseCache(map[string]time.Duration{"/book": time.Minute})
+
+	failure := errors.New("unavailable")
+	if _, _, err := cache.do(context.Background(), "k", "/book", func(context.Context) ([]byte, error) {
+		return nil, failure
+	}); !errors.Is(err, failure) {
+		t.Fatalf("do() error = %v, want %v", err, failure)
+	}
+
+	_, result, err := cache.do(context.Background(), "k", "/book", func(context.Context) ([]byte, error) {
+		return []byte("book"), nil
+	})
+	if err != nil || result != CacheMiss {
+		t.Errorf("do() after error = %s, %v, want a miss", result, err)
+	}
+}
+
+func TestResponseCacheZeroTTLCoalescesOnly(t *testing.T) {
+	cache := NewResponseCache(map[string]time.Duration{"/prices-history": 0})
+
+	for i := 0; i < 2; i++ {
+		_, result, err := cache.do(context.Background(), "k", "/prices-history", func(context.Context) ([]byte, error) {
+			return []byte("history"), nil
+		})
+		if err != nil || result != CacheMiss {
+			t.Errorf("lookup %d = %s, %v, want a miss", i, result, err)
+		}
+	}
+}
+
+func TestResponseCacheCanceledLeader(t *testing.T) {
+	cache := NewResponseCache(map[string]time.Duration{"/book": time.Minute})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	fetch := func(ctx context.Context) ([]byte, error) {
+		close(started)
+		select {
+		case <-release:
+			return []byte("book"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, _, err := cache.do(leaderCtx, "k", "/book", fetch)
+		leaderErr <- err
+	}()
+	<-started
+
+	type outcome struct {
+		body   []byte
+		result string
+		err    error
+	}
+	waiter := make(chan outcome, 1)
+	go func() {
+		body, result, err := cache.do(context.Background(), "k", "/book", fetch)
+		waiter <- outcome{body, result, err}
+	}()
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("leader error = %v, want context.Canceled", err)
+	}
+
+	// Let the waiter join the flight before it completes
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	got := <-waiter
+	if got.err != nil || string(got.body) != "book" || got.result != CacheShared {
+		t.Errorf("waiter = %q, %s, %v, want the shared response", got.body, got.result, got.err)
+	}
+}
+
+func TestCacheMiddlewareCoalescesPublicGets(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/book" {
+			<-release
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	metrics := NewInMemoryMetrics()
+	c := NewClobClient(server.URL, WithCache(NewResponseCache(DefaultCacheTTLs())), WithMetrics(metrics), WithRateLimiter(nil))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.DoGet(context.Background(), "/book", false, map[string]string{"token_id": "1"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("%d requests for 20 concurrent identical GETs, want 1", got)
+	}
+	if got := metrics.CacheLookupCount("/book", CacheMiss) + metrics.CacheLookupCount("/book", CacheShared); got != 20 {
+		t.Errorf("%d lookups reported, want 20", got)
+	}
+
+	// Only GETs are cached
+	requests.Store(0)
+	c.DoGet(context.Background(), "/tick-size", false, nil)
+	c.DoGet(context.Background(), "/tick-size", false, nil)
+	c.DoRequest(context.Background(), http.MethodPost, "/books", nil, false)
+	c.DoRequest(context.Background(), http.MethodPost, "/books", nil, false)
+	if got := requests.Load(); got != 3 {
+		t.Errorf("%d requests, want 3 (one cached GET, two POSTs)", got)
+	}
+}
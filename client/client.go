@@ -1,44 +1,58 @@
 package client
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-	"strconv"
 	"time"
 
+	"github.com/lajosdeme/polymarket-go-api/crypto"
 	"github.com/lajosdeme/polymarket-go-api/types"
 )
 
 // ClobClient represents the main CLOB API client
 type ClobClient struct {
-	baseURL     string
-	httpClient  *http.Client
-	authManager *AuthManager
+	*executor
 }
 
-// NewClobClient creates a new CLOB client
-func NewClobClient(baseURL string) *ClobClient {
+// NewClobClient creates a new CLOB client, signing for Polygon mainnet unless WithChainConfig is given
+func NewClobClient(baseURL string, opts ...Option) *ClobClient {
 	if baseURL == "" {
 		baseURL = "https://clob.polymarket.com"
 	}
 
+	o := newOptions(opts)
+	chainConfig := types.PolygonMainnet
+	if o.chainConfig != nil {
+		chainConfig = *o.chainConfig
+	}
+
 	return &ClobClient{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		authManager: NewAuthManager(),
+		executor: newExecutor(baseURL, o, o.buildHeaders(), clobEndpointGroup, NewAuthManagerWithChainConfig(chainConfig)),
 	}
 }
 
-// SetTimeout sets HTTP client timeout
-func (c *ClobClient) SetTimeout(timeout time.Duration) {
-	c.httpClient.Timeout = timeout
+// NewClobClientWithChainConfig creates a new CLOB client that signs for the given chain,
+// e.g. types.AmoyTestnet or a local fork
+func NewClobClientWithChainConfig(baseURL string, chainConfig types.ChainConfig, opts ...Option) *ClobClient {
+	return NewClobClient(baseURL, append(opts, WithChainConfig(chainConfig))...)
+}
+
+// SetClock sets the clock used for signed headers and order expirations, e.g. a *ServerClock
+func (c *ClobClient) SetClock(clock Clock) {
+	c.authManager.SetClock(clock)
+}
+
+// Now returns the current time of the client's clock
+func (c *ClobClient) Now() time.Time {
+	return c.authManager.Now()
+}
+
+// GetChainConfig returns the chain configuration used for signing
+func (c *ClobClient) GetChainConfig() types.ChainConfig {
+	return c.authManager.GetChainConfig()
 }
 
 // GetAuthManager returns the authentication manager
@@ -51,273 +65,109 @@ func (c *ClobClient) SetupL1Auth(privateKeyHex string, signatureType types.Signa
 	return c.authManager.SetupL1Auth(privateKeyHex, signatureType, funder)
 }
 
+// SetupL1AuthFromKeystore sets up L1 authentication with an encrypted keystore file
+func (c *ClobClient) SetupL1AuthFromKeystore(path, passphrase string, signatureType types.SignatureType, funder string) error {
+	return c.authManager.SetupL1AuthFromKeystore(path, passphrase, signatureType, funder)
+}
+
+// SetupL1AuthWithSigner sets up L1 authentication with an external signer
+func (c *ClobClient) SetupL1AuthWithSigner(signer crypto.Signer, signatureType types.SignatureType, funder string) error {
+	return c.authManager.SetupL1AuthWithSigner(signer, signatureType, funder)
+}
+
 // SetupL2Auth sets up L2 authentication
 func (c *ClobClient) SetupL2Auth(apiKey, secret, passphrase string) error {
 	return c.authManager.SetupL2Auth(apiKey, secret, passphrase)
 }
 
+// RotateAPICredentials atomically replaces the API credentials used for L2 authentication
+func (c *ClobClient) RotateAPICredentials(creds types.APICredentials) error {
+	return c.authManager.RotateAPICredentials(creds)
+}
+
+// ClearAPICredentials removes the API credentials used for L2 authentication
+func (c *ClobClient) ClearAPICredentials() {
+	c.authManager.ClearAPICredentials()
+}
+
 // DoRequest performs an HTTP request with authentication
 func (c *ClobClient) DoRequest(ctx context.Context, method, path string, body interface{}, requireL2Auth bool) ([]byte, error) {
 	// Prepare request body
-	var reqBody []byte
-	if body != nil {
-		var err error
-		reqBody, err = json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-	}
-
-	// Create request
-	requestURL := c.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(reqBody))
+	reqBody, err := marshalBody(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set content type
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		return nil, err
 	}
 
-	// Add authentication headers
+	req := &Request{Method: method, Path: path, Body: reqBody}
 	if requireL2Auth {
-		bodyStr := string(reqBody)
-		headers, err := c.authManager.GenerateL2Headers(method, path, bodyStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate L2 headers: %w", err)
-		}
-		for key, value := range headers {
-			req.Header.Set(key, value)
-		}
-	}
-
-	// Perform request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+		req.Auth = AuthLevelL2
 	}
-
-	return respBody, nil
+	return c.Execute(ctx, req)
 }
 
 // DoRequestWithL1Auth performs an HTTP request with L1 authentication
 func (c *ClobClient) DoRequestWithL1Auth(ctx context.Context, method, path string, body interface{}, nonce uint64, timestamp int64) ([]byte, error) {
 	// Prepare request body
-	var reqBody []byte
-	if body != nil {
-		var err error
-		reqBody, err = json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-	}
-
-	// Create request
-	requestURL := c.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(reqBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set content type
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-
-	// Add L1 authentication headers
-	tstamp := strconv.FormatInt(time.Now().Unix(), 10)
-	headers, err := c.authManager.GenerateL1Headers(tstamp, nonce)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate L1 headers: %w", err)
-	}
-	for key, value := range headers {
-		req.Header.Set(key, value)
-	}
-
-	// Perform request
-	resp, err := c.httpClient.Do(req)
+	reqBody, err := marshalBody(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+		return nil, err
 	}
 
-	return respBody, nil
+	return c.Execute(ctx, &Request{Method: method, Path: path, Body: reqBody, Auth: AuthLevelL1, Nonce: nonce, Timestamp: timestamp})
 }
 
 // DoGet performs a GET request with optional authentication
 func (c *ClobClient) DoGet(ctx context.Context, path string, requireL2Auth bool, queryParams map[string]string) ([]byte, error) {
-	// Build URL with query parameters
-	requestURL := c.baseURL + path
-	if len(queryParams) > 0 {
-		values := url.Values{}
-		for key, value := range queryParams {
-			values.Add(key, value)
-		}
-		requestURL += "?" + values.Encode()
-	}
-
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add authentication headers
+	req := &Request{Method: http.MethodGet, Path: path, Query: queryParams}
 	if requireL2Auth {
-		headers, err := c.authManager.GenerateL2Headers("GET", path, "")
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate L2 headers: %w", err)
-		}
-		for key, value := range headers {
-			req.Header.Set(key, value)
-		}
-	}
-
-	// Perform request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+		req.Auth = AuthLevelL2
 	}
-
-	return respBody, nil
+	return c.Execute(ctx, req)
 }
 
 // DoGetWithL1Auth performs a GET request with L1 authentication
 func (c *ClobClient) DoGetWithL1Auth(ctx context.Context, path string, nonce uint64, timestamp int64, queryParams map[string]string) ([]byte, error) {
-	// Build URL with query parameters
-	requestURL := c.baseURL + path
-	if len(queryParams) > 0 {
-		values := url.Values{}
-		for key, value := range queryParams {
-			values.Add(key, value)
-		}
-		requestURL += "?" + values.Encode()
-	}
-
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add L1 authentication headers
-	tstamp := strconv.FormatInt(time.Now().Unix(), 10)
-	headers, err := c.authManager.GenerateL1Headers(tstamp, nonce)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate L1 headers: %w", err)
-	}
-	for key, value := range headers {
-		req.Header.Set(key, value)
-	}
-
-	// Perform request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
-	}
+	return c.Execute(ctx, &Request{Method: http.MethodGet, Path: path, Query: queryParams, Auth: AuthLevelL1, Nonce: nonce, Timestamp: timestamp})
+}
 
-	return respBody, nil
+// DoGetWithCredentials performs a GET request with L2 authentication signed by creds
+func (c *ClobClient) DoGetWithCredentials(ctx context.Context, path string, creds types.APICredentials, queryParams map[string]string) ([]byte, error) {
+	return c.Execute(ctx, &Request{Method: http.MethodGet, Path: path, Query: queryParams, Auth: AuthLevelL2, Credentials: &creds})
 }
 
 // DoDelete performs a DELETE request with authentication
 func (c *ClobClient) DoDelete(ctx context.Context, path string, body interface{}) ([]byte, error) {
 	// Prepare request body
-	var reqBody []byte
-	if body != nil {
-		var err error
-		reqBody, err = json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-	}
-
-	// Create request
-	requestURL := c.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, bytes.NewReader(reqBody))
+	reqBody, err := marshalBody(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set content type
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		return nil, err
 	}
 
-	// Add authentication headers
-	bodyStr := string(reqBody)
-	headers, err := c.authManager.GenerateL2Headers("DELETE", path, bodyStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate L2 headers: %w", err)
-	}
-	for key, value := range headers {
-		req.Header.Set(key, value)
-	}
+	return c.Execute(ctx, &Request{Method: http.MethodDelete, Path: path, Body: reqBody, Auth: AuthLevelL2})
+}
 
-	// Perform request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
+// marshalBody encodes a request body as JSON, returning nil for a nil body
+func marshalBody(body interface{}) ([]byte, error) {
+	if body == nil {
+		return nil, nil
 	}
-	defer resp.Body.Close()
 
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	reqBody, err := json.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
+	return reqBody, nil
+}
 
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+// buildURL joins a base URL, path and query parameters
+func buildURL(baseURL, path string, queryParams map[string]string) string {
+	requestURL := baseURL + path
+	if len(queryParams) > 0 {
+		values := url.Values{}
+		for key, value := range queryParams {
+			values.Add(key, value)
+		}
+		requestURL += "?" + values.Encode()
 	}
-
-	return respBody, nil
+	return requestURL
 }
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time used for signed headers and order expirations
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the local clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ServerTimeFunc returns the server time in unix seconds, e.g. api.AuthAPI.GetServerTime
+type ServerTimeFunc func(ctx context.Context) (int64, error)
+
+// ServerClock follows the server's clock by keeping the offset between it and the local clock,
+// so signatures stay valid on hosts with a skewed clock. It is safe for concurrent use.
+type ServerClock struct {
+	serverTime ServerTimeFunc
+
+	mu       sync.RWMutex
+	offset   time.Duration
+	lastSync time.Time
+	lastErr  error
+}
+
+// NewServerClock creates a server clock; it follows the local clock until the first Sync
+func NewServerClock(serverTime ServerTimeFunc) *ServerClock {
+	return &ServerClock{
+		serverTime: serverTime,
+	}
+}
+
+// Now returns the current server time
+func (c *ServerClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return time.Now().Add(c.offset)
+}
+
+// Offset returns the server time minus the local time
+func (c *ServerClock) Offset() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.offset
+}
+
+// LastSync returns the local time of the last successful sync and the error of the last sync, if any
+func (c *ServerClock) LastSync() (time.Time, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.lastSync, c.lastErr
+}
+
+// Sync fetches the server time and updates the offset.
+// The server answers in whole seconds, so the offset is accurate to about half a second.
+func (c *ServerClock) Sync(ctx context.Context) error {
+	start := time.Now()
+	serverSeconds, err := c.serverTime(ctx)
+	end := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		c.lastErr = fmt.Errorf("failed to sync server time: %w", err)
+		return c.lastErr
+	}
+
+	// Assume the server read its clock halfway through the round trip and
+	// halfway through the second it reported
+	local := start.Add(end.Sub(start) / 2)
+	server := time.Unix(serverSeconds, int64(500*time.Millisecond))
+
+	c.offset = server.Sub(local)
+	c.lastSync = end
+	c.lastErr = nil
+	return nil
+}
+
+// Start syncs once and then keeps syncing every interval in the background until ctx is done.
+// A failed background sync keeps the previous offset; see LastSync.
+func (c *ServerClock) Start(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("sync interval must be positive")
+	}
+
+	if err := c.Sync(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.Sync(ctx)
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// executor sends the requests of ClobClient and GammaClient through one middleware chain.
+// The chain is built once and rebuilt only when a setter changes it, so setters are safe
+// to call while requests are in flight.
+type executor struct {
+	baseURL     string
+	httpClient  *http.Client
+	headers     http.Header
+	group       func(method, path string) EndpointGroup
+	authManager *AuthManager
+	logger      *slog.Logger
+	metrics     Metrics
+	tracer      Tracer
+
+	mu          sync.Mutex
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	cache       *ResponseCache
+	breaker     *CircuitBreaker
+	failover    []string
+	middlewares []Middleware
+	handler     atomic.Pointer[Handler]
+}
+
+// newExecutor creates an executor for baseURL configured by o. group assigns requests without
+// a group to an endpoint group; a nil authManager sends requests without authentication.
+func newExecutor(baseURL string, o *options, headers http.Header, group func(method, path string) EndpointGroup, authManager *AuthManager) *executor {
+	e := &executor{
+		baseURL:     baseURL,
+		httpClient:  o.buildHTTPClient(),
+		headers:     headers,
+		group:       group,
+		authManager: authManager,
+		logger:      o.logger,
+		metrics:     o.metrics,
+		tracer:      o.tracer,
+		retryPolicy: o.buildRetryPolicy(),
+		rateLimiter: o.buildRateLimiter(),
+		cache:       o.cache,
+		breaker:     o.breaker,
+		failover:    o.failover,
+	}
+	e.build()
+	return e
+}
+
+// build rebuilds the middleware chain; e.mu must be held unless e is not yet shared
+func (e *executor) build() {
+	middlewares := append([]Middleware{}, e.middlewares...)
+	middlewares = append(middlewares,
+		cacheMiddleware(e.cache, e.baseURL, e.metrics),
+		TracingMiddleware(e.tracer),
+		RetryMiddleware(e.retryPolicy),
+		CircuitBreakerMiddleware(e.breaker, e.baseURL, e.failover...),
+		RateLimitMiddleware(e.rateLimiter),
+		MetricsMiddleware(e.metrics),
+		LoggingMiddleware(e.logger),
+	)
+	if e.authManager != nil {
+		middlewares = append(middlewares, AuthMiddleware(e.authManager))
+	}
+
+	handler := chain(failoverTransport(e.baseURL, e.failover, e.httpClient, e.logger, e.breaker), middlewares...)
+	e.handler.Store(&handler)
+}
+
+// Execute sends a request through the client's middleware chain
+func (e *executor) Execute(ctx context.Context, req *Request) ([]byte, error) {
+	req = req.clone()
+	if req.Group == "" {
+		req.Group = e.group(req.Method, req.Path)
+	}
+	setDefaultHeaders(req.Header, e.headers)
+
+	return (*e.handler.Load())(ctx, req)
+}
+
+// Use registers middlewares that wrap every request of the client, the first being the outermost.
+// They run before the built-in cache, tracing, retry, circuit breaker, rate limit, metrics,
+// logging and (for ClobClient) authentication middlewares.
+func (e *executor) Use(middlewares ...Middleware) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.middlewares = append(e.middlewares, middlewares...)
+	e.build()
+}
+
+// SetTimeout sets HTTP client timeout
+func (e *executor) SetTimeout(timeout time.Duration) {
+	e.httpClient.Timeout = timeout
+}
+
+// SetRetryPolicy sets the retry policy for failed requests
+func (e *executor) SetRetryPolicy(policy RetryPolicy) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.retryPolicy = policy
+	e.build()
+}
+
+// SetRateLimiter sets the rate limiter pacing requests; nil disables rate limiting.
+// A limiter can be shared by several clients to pace them against one budget.
+func (e *executor) SetRateLimiter(limiter *RateLimiter) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.rateLimiter = limiter
+	e.build()
+}
+
+// GetRateLimiter returns the rate limiter pacing requests
+func (e *executor) GetRateLimiter() *RateLimiter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.rateLimiter
+}
+
+// SetCache sets the response cache for public GET requests; nil disables caching
+func (e *executor) SetCache(cache *ResponseCache) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.cache = cache
+	e.build()
+}
+
+// GetCache returns the response cache, nil if caching is disabled
+func (e *executor) GetCache() *ResponseCache {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.cache
+}
+
+// SetCircuitBreaker sets the circuit breaker; nil disables it
+func (e *executor) SetCircuitBreaker(breaker *CircuitBreaker) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.breaker = breaker
+	e.build()
+}
+
+// GetCircuitBreaker returns the circuit breaker, nil if it is disabled
+func (e *executor) GetCircuitBreaker() *CircuitBreaker {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.breaker
+}
+
+// SetFailoverURLs sets the alternate base URLs for read-only requests
+func (e *executor) SetFailoverURLs(baseURLs ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.failover = append([]string(nil), baseURLs...)
+	e.build()
+}
+
+// GetMetrics returns the metrics receiving the client's measurements
+func (e *executor) GetMetrics() Metrics {
+	return e.metrics
+}
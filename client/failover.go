@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// readOnlyPosts are POST endpoints that only read data
+var readOnlyPosts = map[string]bool{
+	"/books":              true,
+	"/prices":             true,
+	"/spreads":            true,
+	"/midpoints":          true,
+	"/last-trades-prices": true,
+}
+
+// mayFailOver reports whether a request may be sent to an alternate server: it only reads
+// data and carries no credentials, which must never reach another host
+func mayFailOver(req *Request) bool {
+	if req.Auth != AuthLevelNone {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return readOnlyPosts[req.Path]
+	default:
+		return false
+	}
+}
+
+// failoverTransport sends requests to baseURL through breaker. Requests that may fail over and
+// fail with a server error or timeout, or whose circuit is open, are sent to the alternate
+// base URLs in order until one succeeds.
+func failoverTransport(baseURL string, alternates []string, httpClient *http.Client, logger *slog.Logger, breaker *CircuitBreaker) Handler {
+	baseURLs := append([]string{baseURL}, alternates...)
+	handlers := make([]Handler, len(baseURLs))
+	for i, target := range baseURLs {
+		handlers[i] = transport(target, httpClient)
+	}
+
+	return func(ctx context.Context, req *Request) ([]byte, error) {
+		targets := 1
+		if mayFailOver(req) {
+			targets = len(baseURLs)
+		}
+
+		var err error
+		for i := 0; i < targets; i++ {
+			if i > 0 {
+				if (!isServerFailure(err) && !isCircuitOpen(err)) || ctx.Err() != nil {
+					break
+				}
+				logger.LogAttrs(ctx, slog.LevelWarn, "failing over to alternate base URL",
+					slog.String("method", req.Method),
+					slog.String("path", req.Path),
+					slog.String("base_url", baseURLs[i]),
+					slog.String("error", err.Error()),
+				)
+			}
+
+			var body []byte
+			if body, err = breaker.send(ctx, baseURLs[i], req, handlers[i]); err == nil {
+				return body, nil
+			}
+		}
+
+		return nil, err
+	}
+}
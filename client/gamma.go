@@ -1,130 +1,50 @@
 package client
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
 	"strconv"
-	"time"
 
 	"github.com/lajosdeme/polymarket-go-api/types"
 )
 
 // GammaClient represents the Gamma API client for market data
 type GammaClient struct {
-	baseURL    string
-	httpClient *http.Client
+	*executor
 }
 
 // NewGammaClient creates a new Gamma client
-func NewGammaClient(baseURL string) *GammaClient {
+func NewGammaClient(baseURL string, opts ...Option) *GammaClient {
 	if baseURL == "" {
 		baseURL = "https://gamma-api.polymarket.com"
 	}
 
-	return &GammaClient{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	o := newOptions(opts)
+	headers := o.buildHeaders()
+	if headers.Get("Accept") == "" {
+		headers.Set("Accept", "application/json")
 	}
-}
 
-// SetTimeout sets HTTP client timeout
-func (c *GammaClient) SetTimeout(timeout time.Duration) {
-	c.httpClient.Timeout = timeout
+	return &GammaClient{
+		executor: newExecutor(baseURL, o, headers, gammaEndpointGroup, nil),
+	}
 }
 
 // DoGet performs a GET request to the Gamma API
 func (c *GammaClient) DoGet(ctx context.Context, path string, queryParams map[string]string) ([]byte, error) {
-	// Build URL with query parameters
-	requestURL := c.baseURL + path
-	if len(queryParams) > 0 {
-		values := url.Values{}
-		for key, value := range queryParams {
-			values.Add(key, value)
-		}
-		requestURL += "?" + values.Encode()
-	}
-
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Accept", "application/json")
-
-	// Perform request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
-	}
-
-	return respBody, nil
+	return c.Execute(ctx, &Request{Method: http.MethodGet, Path: path, Query: queryParams})
 }
 
 // DoPost performs a POST request to the Gamma API
 func (c *GammaClient) DoPost(ctx context.Context, path string, body interface{}) ([]byte, error) {
 	// Prepare request body
-	var reqBody []byte
-	var err error
-	if body != nil {
-		reqBody, err = json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-	}
-
-	// Create request
-	requestURL := c.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, "POST", requestURL, bytes.NewReader(reqBody))
+	reqBody, err := marshalBody(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	req.Header.Set("Accept", "application/json")
-
-	// Perform request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+		return nil, err
 	}
 
-	return respBody, nil
+	return c.Execute(ctx, &Request{Method: http.MethodPost, Path: path, Body: reqBody})
 }
 
 // BuildQueryParams converts filter structs to query parameters map
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// sensitiveHeaders are headers whose values are never logged
+var sensitiveHeaders = map[string]bool{
+	"poly_signature":  true,
+	"poly_passphrase": true,
+	"poly_api_key":    true,
+	"authorization":   true,
+	"cookie":          true,
+}
+
+// RedactHeaders returns a copy of header with signatures, passphrases and API keys redacted,
+// safe to log
+func RedactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for key := range redacted {
+		if sensitiveHeaders[strings.ToLower(key)] {
+			redacted[key] = []string{types.Redacted}
+		}
+	}
+	return redacted
+}
+
+// responseInfo carries details of the last response from the transport to outer middlewares
+type responseInfo struct {
+	statusCode int
+}
+
+type responseInfoKey struct{}
+
+// withResponseInfo returns a context carrying a responseInfo, reusing one already present
+func withResponseInfo(ctx context.Context) (context.Context, *responseInfo) {
+	if info, ok := ctx.Value(responseInfoKey{}).(*responseInfo); ok {
+		return ctx, info
+	}
+
+	info := &responseInfo{}
+	return context.WithValue(ctx, responseInfoKey{}, info), info
+}
+
+// recordResponse stores the status code of a response in the context's responseInfo, if any
+func recordResponse(ctx context.Context, statusCode int) {
+	if info, ok := ctx.Value(responseInfoKey{}).(*responseInfo); ok {
+		info.statusCode = statusCode
+	}
+}
+
+// LoggingMiddleware logs every attempt of a request with its status, latency and outcome.
+// Headers are only logged at debug level and with secrets redacted.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			ctx, info := withResponseInfo(ctx)
+			info.statusCode = 0
+
+			start := time.Now()
+			body, err := next(ctx, req)
+
+			attrs := []any{
+				"method", req.Method,
+				"path", req.Path,
+				"status", info.statusCode,
+				"attempt", req.Attempt,
+				"latency", time.Since(start),
+			}
+			if err != nil {
+				logger.WarnContext(ctx, "request failed", append(attrs, "error", err)...)
+			} else if logger.Enabled(ctx, slog.LevelDebug) {
+				logger.DebugContext(ctx, "request completed", append(attrs, "headers", RedactHeaders(req.Header))...)
+			}
+
+			return body, err
+		}
+	}
+}
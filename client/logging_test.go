@@ -0,0 +1,160 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+func TestLogsRedactSecrets(t *testing.T) {
+	const (
+		privateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+		apiKey     = "0f8a3b2c-api-key-never-logged"
+		secret     = "c2VjcmV0LW5ldmVyLWxvZ2dlZA=="
+		passphrase = "passphrase-never-logged"
+	)
+
+	// The server records the signatures it receives, which must not be logged either
+	var mu sync.Mutex
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		signatures = append(signatures, r.Header.Get("POLY_SIGNATURE"))
+		mu.Unlock()
+
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid request"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	channel := &userChannelServer{open: make(map[*websocket.Conn]string)}
+	wsServer := httptest.NewServer(channel)
+	defer wsServer.Close()
+
+	for _, format := range []string{"json", "text"} {
+		var buf bytes.Buffer
+		var logMu sync.Mutex
+		options := &slog.HandlerOptions{Level: slog.LevelDebug}
+		var handler slog.Handler = slog.NewJSONHandler(&lockedWriter{mu: &logMu, w: &buf}, options)
+		if format == "text" {
+			handler = slog.NewTextHandler(&lockedWriter{mu: &logMu, w: &buf}, options)
+		}
+		logger := slog.New(handler)
+
+		c := NewClobClient(server.URL, WithLogger(logger), WithRetryPolicy(NoRetryPolicy()))
+		if err := c.SetupL1Auth(privateKey, types.EOA, ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.SetupL2Auth(apiKey, secret, passphrase); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx := context.Background()
+		if _, err := c.DoGetWithL1Auth(ctx, "/auth/derive-api-key", 0, 0, nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.DoGet(ctx, "/data/orders", true, nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.DoGet(ctx, "/fail", true, nil); err == nil {
+			t.Fatal("expected an error from /fail")
+		}
+
+		// Headers reaching LoggingMiddleware are redacted, whatever their case
+		headers, err := c.GetAuthManager().GenerateL2Headers(http.MethodGet, "/data/orders", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := &Request{Method: http.MethodGet, Path: "/data/orders", Header: http.Header{}}
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		req.Header["poly_passphrase"] = []string{passphrase}
+		req.Header.Set("Authorization", "Bearer "+secret)
+		mu.Lock()
+		signatures = append(signatures, headers["POLY_SIGNATURE"])
+		mu.Unlock()
+		handle := LoggingMiddleware(logger)(func(context.Context, *Request) ([]byte, error) {
+			return []byte(`{}`), nil
+		})
+		if _, err := handle(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+		if req.Header.Get("POLY_API_KEY") != apiKey {
+			t.Error("RedactHeaders modified the request headers")
+		}
+
+		// The user channel logs its auth on connecting and re-authenticating
+		ws := NewWebSocketClient("ws"+strings.TrimPrefix(wsServer.URL, "http"), c.GetAuthManager(), WithLogger(logger))
+		if err := ws.ConnectUserChannel([]string{"0x1"}); err != nil {
+			t.Fatal(err)
+		}
+		rotated := types.APICredentials{APIKey: apiKey + "-2", Secret: secret + "2", Passphrase: passphrase + "-2"}
+		if err := c.RotateAPICredentials(rotated); err != nil {
+			t.Fatal(err)
+		}
+		waitForKeys(t, channel, rotated.APIKey)
+		ws.Close()
+
+		// Wait for the re-authentication to be logged
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			logMu.Lock()
+			logged := buf.String()
+			logMu.Unlock()
+			if strings.Contains(logged, "websocket re-authenticated") {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("%s: re-authentication not logged:\n%s", format, logged)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		logMu.Lock()
+		logged := buf.String()
+		logMu.Unlock()
+
+		mu.Lock()
+		secrets := append([]string{privateKey, apiKey, secret, passphrase, rotated.Secret, rotated.Passphrase}, signatures...)
+		mu.Unlock()
+		for _, s := range secrets {
+			if s != "" && strings.Contains(logged, s) {
+				t.Errorf("%s: secret %q logged:\n%s", format, s, logged)
+			}
+		}
+
+		// The logs still carry the redacted values and masked keys
+		for _, want := range []string{"request completed", "request failed", "websocket connected", "Poly_address", types.Redacted, types.MaskAPIKey(apiKey)} {
+			if !strings.Contains(logged, want) {
+				t.Errorf("%s: %q not logged:\n%s", format, want, logged)
+			}
+		}
+	}
+}
+
+// lockedWriter serialises writes to w, which the client and WebSocket goroutines share
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  *bytes.Buffer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.w.Write(p)
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// RequestObservation describes one attempt of an HTTP request
+type RequestObservation struct {
+	Group  EndpointGroup
+	Method string
+	// Endpoint is the request path with IDs and slugs replaced by placeholders, e.g. "/markets/{id}"
+	Endpoint string
+	// Status is the HTTP status code, zero when no response was received
+	Status  int
+	Attempt int
+	Latency time.Duration
+	Err     error
+}
+
+// Metrics receives measurements of SDK behaviour and must be safe for concurrent use.
+// Embed NopMetrics in implementations to stay compatible when methods are added.
+type Metrics interface {
+	// ObserveRequest is called for every attempt of an HTTP request
+	ObserveRequest(observation RequestObservation)
+	// ObserveOrder is called for every placed order with its status (e.g. "matched", "live")
+	// or the error code it was rejected with
+	ObserveOrder(outcome string)
+	// ObserveWebSocketMessage is called for every WebSocket event with the latency of its handler
+	ObserveWebSocketMessage(channel types.WebSocketChannelType, eventType types.WebSocketEventType, handlerLatency time.Duration)
+	// ObserveWebSocketConnect is called for every WebSocket connection; reconnect is true
+	// when the client was connected before
+	ObserveWebSocketConnect(channel types.WebSocketChannelType, reconnect bool)
+	// ObserveCacheLookup is called for every request served through the response cache with
+	// CacheHit, CacheMiss or CacheShared
+	ObserveCacheLookup(endpoint, result string)
+}
+
+// NopMetrics discards all measurements
+type NopMetrics struct{}
+
+// ObserveRequest implements Metrics
+func (NopMetrics) ObserveRequest(RequestObservation) {}
+
+// ObserveOrder implements Metrics
+func (NopMetrics) ObserveOrder(string) {}
+
+// ObserveWebSocketMessage implements Metrics
+func (NopMetrics) ObserveWebSocketMessage(types.WebSocketChannelType, types.WebSocketEventType, time.Duration) {
+}
+
+// ObserveWebSocketConnect implements Metrics
+func (NopMetrics) ObserveWebSocketConnect(types.WebSocketChannelType, bool) {}
+
+// ObserveCacheLookup implements Metrics
+func (NopMetrics) ObserveCacheLookup(string, string) {}
+
+// Span is a traced operation
+type Span interface {
+	// SetAttribute adds an attribute to the span
+	SetAttribute(key, value string)
+	// End finishes the span, recording err if not nil
+	End(err error)
+}
+
+// Tracer starts spans, e.g. an adapter to OpenTelemetry
+type Tracer interface {
+	// StartSpan starts a span and returns a context carrying it
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// nopTracer starts spans that do nothing
+type nopTracer struct{}
+
+type nopSpan struct{}
+
+func (nopTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+func (nopSpan) SetAttribute(key, value string) {}
+
+func (nopSpan) End(err error) {}
+
+// MetricsMiddleware reports every attempt of a request to metrics
+func MetricsMiddleware(metrics Metrics) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			ctx, info := withResponseInfo(ctx)
+			info.statusCode = 0
+
+			start := time.Now()
+			body, err := next(ctx, req)
+
+			metrics.ObserveRequest(RequestObservation{
+				Group:    req.Group,
+				Method:   req.Method,
+				Endpoint: EndpointLabel(req.Path),
+				Status:   info.statusCode,
+				Attempt:  req.Attempt,
+				Latency:  time.Since(start),
+				Err:      err,
+			})
+
+			return body, err
+		}
+	}
+}
+
+// TracingMiddleware wraps every request, including all its attempts, in a span
+func TracingMiddleware(tracer Tracer) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			ctx, span := tracer.StartSpan(ctx, "polymarket "+req.Method+" "+EndpointLabel(req.Path))
+			span.SetAttribute("http.method", req.Method)
+			span.SetAttribute("http.route", EndpointLabel(req.Path))
+			span.SetAttribute("polymarket.endpoint_group", string(req.Group))
+
+			ctx, info := withResponseInfo(ctx)
+			body, err := next(ctx, req)
+
+			if info.statusCode != 0 {
+				span.SetAttribute("http.status_code", strconv.Itoa(info.statusCode))
+			}
+			span.End(err)
+
+			return body, err
+		}
+	}
+}
+
+// EndpointLabel replaces IDs and slugs in a path with placeholders, keeping metric labels bounded
+func EndpointLabel(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case i > 0 && segments[i-1] == "slug":
+			segments[i] = "{slug}"
+		case isIDSegment(segment):
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isIDSegment reports whether a path segment is a numeric or hex ID
+func isIDSegment(segment string) bool {
+	if strings.HasPrefix(segment, "0x") {
+		return true
+	}
+	if segment == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(segment, 10, 64)
+	return err == nil
+}
+
+// ObserveOrders reports the outcome of placed orders to the client's metrics.
+// It is called by the order placement methods of the api package.
+func (c *ClobClient) ObserveOrders(responses []types.OrderResponse, err error) {
+	if len(responses) == 0 && err != nil {
+		outcome := "error"
+		if clobErr, ok := types.AsClobError(err); ok {
+			outcome = string(clobErr.Code)
+		}
+		c.metrics.ObserveOrder(outcome)
+		return
+	}
+
+	for i := range responses {
+		outcome := responses[i].Status
+		if clobErr := types.NewOrderResponseError(&responses[i]); clobErr != nil {
+			outcome = string(clobErr.Code)
+		} else if outcome == "" {
+			outcome = "success"
+		}
+		c.metrics.ObserveOrder(outcome)
+	}
+}
@@ -0,0 +1,379 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// latencyBuckets are the upper bounds in seconds of the latency histograms
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// histogram is a cumulative latency histogram
+type histogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+func (h *histogram) observe(latency time.Duration) {
+	if h.buckets == nil {
+		h.buckets = make([]uint64, len(latencyBuckets))
+	}
+
+	seconds := latency.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+type requestKey struct {
+	group    EndpointGroup
+	method   string
+	endpoint string
+}
+
+type requestStatusKey struct {
+	requestKey
+	status int
+}
+
+type requestErrorKey struct {
+	requestKey
+	code string
+}
+
+type cacheLookupKey struct {
+	endpoint string
+	result   string
+}
+
+type webSocketKey struct {
+	channel   types.WebSocketChannelType
+	eventType types.WebSocketEventType
+}
+
+// InMemoryMetrics keeps measurements in memory and exports them in the Prometheus text format
+type InMemoryMetrics struct {
+	mu                  sync.Mutex
+	requests            map[requestStatusKey]uint64
+	requestErrors       map[requestErrorKey]uint64
+	requestLatency      map[requestKey]*histogram
+	orders              map[string]uint64
+	webSocketMessages   map[webSocketKey]*histogram
+	webSocketConnects   map[types.WebSocketChannelType]uint64
+	webSocketReconnects map[types.WebSocketChannelType]uint64
+	cacheLookups        map[cacheLookupKey]uint64
+}
+
+// NewInMemoryMetrics creates an empty in-memory metrics store
+func NewInMemoryMetrics() *InMemoryMetrics {
+	return &InMemoryMetrics{
+		requests:            make(map[requestStatusKey]uint64),
+		requestErrors:       make(map[requestErrorKey]uint64),
+		requestLatency:      make(map[requestKey]*histogram),
+		orders:              make(map[string]uint64),
+		webSocketMessages:   make(map[webSocketKey]*histogram),
+		webSocketConnects:   make(map[types.WebSocketChannelType]uint64),
+		webSocketReconnects: make(map[types.WebSocketChannelType]uint64),
+		cacheLookups:        make(map[cacheLookupKey]uint64),
+	}
+}
+
+// ObserveRequest implements Metrics
+func (m *InMemoryMetrics) ObserveRequest(observation RequestObservation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := requestKey{group: observation.Group, method: observation.Method, endpoint: observation.Endpoint}
+	m.requests[requestStatusKey{requestKey: key, status: observation.Status}]++
+
+	if observation.Err != nil {
+		code := "transport"
+		if clobErr, ok := types.AsClobError(observation.Err); ok {
+			code = string(clobErr.Code)
+		}
+		m.requestErrors[requestErrorKey{requestKey: key, code: code}]++
+	}
+
+	h := m.requestLatency[key]
+	if h == nil {
+		h = &histogram{}
+		m.requestLatency[key] = h
+	}
+	h.observe(observation.Latency)
+}
+
+// ObserveOrder implements Metrics
+func (m *InMemoryMetrics) ObserveOrder(outcome string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.orders[outcome]++
+}
+
+// ObserveWebSocketMessage implements Metrics
+func (m *InMemoryMetrics) ObserveWebSocketMessage(channel types.WebSocketChannelType, eventType types.WebSocketEventType, handlerLatency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := webSocketKey{channel: channel, eventType: eventType}
+	h := m.webSocketMessages[key]
+	if h == nil {
+		h = &histogram{}
+		m.webSocketMessages[key] = h
+	}
+	h.observe(handlerLatency)
+}
+
+// ObserveWebSocketConnect implements Metrics
+func (m *InMemoryMetrics) ObserveWebSocketConnect(channel types.WebSocketChannelType, reconnect bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.webSocketConnects[channel]++
+	if reconnect {
+		m.webSocketReconnects[channel]++
+	}
+}
+
+// ObserveCacheLookup implements Metrics
+func (m *InMemoryMetrics) ObserveCacheLookup(endpoint, result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cacheLookups[cacheLookupKey{endpoint: endpoint, result: result}]++
+}
+
+// RequestCount returns the number of request attempts to an endpoint, e.g. ("GET", "/book")
+func (m *InMemoryMetrics) RequestCount(method, endpoint string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count uint64
+	for key, n := range m.requests {
+		if key.method == method && key.endpoint == endpoint {
+			count += n
+		}
+	}
+	return count
+}
+
+// ErrorCount returns the number of failed request attempts to an endpoint
+func (m *InMemoryMetrics) ErrorCount(method, endpoint string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count uint64
+	for key, n := range m.requestErrors {
+		if key.method == method && key.endpoint == endpoint {
+			count += n
+		}
+	}
+	return count
+}
+
+// OrderCount returns the number of placed orders with an outcome
+func (m *InMemoryMetrics) OrderCount(outcome string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.orders[outcome]
+}
+
+// WebSocketMessageCount returns the number of WebSocket events of a type received on a channel
+func (m *InMemoryMetrics) WebSocketMessageCount(channel types.WebSocketChannelType, eventType types.WebSocketEventType) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if h := m.webSocketMessages[webSocketKey{channel: channel, eventType: eventType}]; h != nil {
+		return h.count
+	}
+	return 0
+}
+
+// WebSocketReconnectCount returns the number of reconnects on a channel
+func (m *InMemoryMetrics) WebSocketReconnectCount(channel types.WebSocketChannelType) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.webSocketReconnects[channel]
+}
+
+// CacheLookupCount returns the number of cache lookups for an endpoint with a result, e.g. ("/book", CacheHit)
+func (m *InMemoryMetrics) CacheLookupCount(endpoint, result string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.cacheLookups[cacheLookupKey{endpoint: endpoint, result: result}]
+}
+
+// WritePrometheus writes all metrics in the Prometheus text exposition format
+func (m *InMemoryMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pw := &promWriter{w: bufio.NewWriter(w)}
+
+	pw.header("polymarket_requests_total", "counter", "HTTP request attempts by endpoint and status code.")
+	for _, key := range sortedKeys(m.requests, func(k requestStatusKey) string {
+		return k.requestKey.String() + strconv.Itoa(k.status)
+	}) {
+		pw.sample("polymarket_requests_total", key.labels("status", strconv.Itoa(key.status)), float64(m.requests[key]))
+	}
+
+	pw.header("polymarket_request_errors_total", "counter", "Failed HTTP request attempts by endpoint and error code.")
+	for _, key := range sortedKeys(m.requestErrors, func(k requestErrorKey) string {
+		return k.requestKey.String() + k.code
+	}) {
+		pw.sample("polymarket_request_errors_total", key.labels("code", key.code), float64(m.requestErrors[key]))
+	}
+
+	pw.header("polymarket_request_duration_seconds", "histogram", "HTTP request attempt latency.")
+	for _, key := range sortedKeys(m.requestLatency, requestKey.String) {
+		pw.histogram("polymarket_request_duration_seconds", key.labels(), m.requestLatency[key])
+	}
+
+	pw.header("polymarket_orders_total", "counter", "Placed orders by status or rejection code.")
+	for _, outcome := range sortedKeys(m.orders, func(k string) string { return k }) {
+		pw.sample("polymarket_orders_total", []string{"outcome", outcome}, float64(m.orders[outcome]))
+	}
+
+	pw.header("polymarket_ws_messages_total", "counter", "WebSocket events by channel and event type.")
+	for _, key := range sortedKeys(m.webSocketMessages, webSocketKey.String) {
+		pw.sample("polymarket_ws_messages_total", key.labels(), float64(m.webSocketMessages[key].count))
+	}
+
+	pw.header("polymarket_ws_handler_duration_seconds", "histogram", "WebSocket event handler latency.")
+	for _, key := range sortedKeys(m.webSocketMessages, webSocketKey.String) {
+		pw.histogram("polymarket_ws_handler_duration_seconds", key.labels(), m.webSocketMessages[key])
+	}
+
+	pw.header("polymarket_ws_connects_total", "counter", "WebSocket connections by channel.")
+	for _, channel := range sortedKeys(m.webSocketConnects, channelString) {
+		pw.sample("polymarket_ws_connects_total", []string{"channel", string(channel)}, float64(m.webSocketConnects[channel]))
+	}
+
+	pw.header("polymarket_ws_reconnects_total", "counter", "WebSocket reconnections by channel.")
+	for _, channel := range sortedKeys(m.webSocketReconnects, channelString) {
+		pw.sample("polymarket_ws_reconnects_total", []string{"channel", string(channel)}, float64(m.webSocketReconnects[channel]))
+	}
+
+	pw.header("polymarket_cache_lookups_total", "counter", "Response cache lookups by endpoint and result.")
+	for _, key := range sortedKeys(m.cacheLookups, cacheLookupKey.String) {
+		pw.sample("polymarket_cache_lookups_total", []string{"endpoint", key.endpoint, "result", key.result}, float64(m.cacheLookups[key]))
+	}
+
+	if pw.err != nil {
+		return fmt.Errorf("failed to write metrics: %w", pw.err)
+	}
+	if err := pw.w.Flush(); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+	return nil
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format, e.g. on /metrics
+func (m *InMemoryMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WritePrometheus(w)
+}
+
+func (k requestKey) String() string {
+	return string(k.group) + " " + k.method + " " + k.endpoint + " "
+}
+
+func (k requestKey) labels(extra ...string) []string {
+	return append([]string{"group", string(k.group), "method", k.method, "endpoint", k.endpoint}, extra...)
+}
+
+func (k cacheLookupKey) String() string {
+	return k.endpoint + " " + k.result
+}
+
+func (k webSocketKey) String() string {
+	return string(k.channel) + " " + string(k.eventType)
+}
+
+func (k webSocketKey) labels() []string {
+	return []string{"channel", string(k.channel), "event_type", string(k.eventType)}
+}
+
+func channelString(channel types.WebSocketChannelType) string {
+	return string(channel)
+}
+
+// sortedKeys returns the keys of m sorted by their string form, for stable output
+func sortedKeys[K comparable, V any](m map[K]V, str func(K) string) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return str(keys[i]) < str(keys[j])
+	})
+	return keys
+}
+
+// promWriter writes the Prometheus text format, keeping the first error
+type promWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (p *promWriter) printf(format string, args ...any) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
+
+func (p *promWriter) header(name, metricType, help string) {
+	p.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+func (p *promWriter) sample(name string, labels []string, value float64) {
+	p.printf("%s%s %s\n", name, formatLabels(labels), strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (p *promWriter) histogram(name string, labels []string, h *histogram) {
+	for i, bound := range latencyBuckets {
+		le := strconv.FormatFloat(bound, 'g', -1, 64)
+		p.sample(name+"_bucket", append(labels[:len(labels):len(labels)], "le", le), float64(h.buckets[i]))
+	}
+	p.sample(name+"_bucket", append(labels[:len(labels):len(labels)], "le", "+Inf"), float64(h.count))
+	p.sample(name+"_sum", labels, h.sum)
+	p.sample(name+"_count", labels, float64(h.count))
+}
+
+// labelEscaper escapes label values as required by the text format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels formats alternating label names and values
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(labels); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `%s="%s"`, labels[i], labelEscaper.Replace(labels[i+1]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
@@ -0,0 +1,266 @@
+package client
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// defaultTimeout is the HTTP timeout of clients created without WithHTTPClient
+const defaultTimeout = 30 * time.Second
+
+// Option configures a ClobClient, GammaClient or WebSocketClient.
+// Options that do not apply to a client, e.g. WithDialer for a ClobClient, are ignored.
+type Option func(*options)
+
+// options holds the configuration collected from Options
+type options struct {
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	timeout     time.Duration
+	proxy       *url.URL
+	userAgent   string
+	headers     http.Header
+	logger      *slog.Logger
+	metrics     Metrics
+	tracer      Tracer
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+	noRateLimit bool
+	cache       *ResponseCache
+	breaker     *CircuitBreaker
+	failover    []string
+	dialer      *websocket.Dialer
+	chainConfig *types.ChainConfig
+}
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the transport of the HTTP client
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithTimeout sets the HTTP client timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithProxy routes HTTP and WebSocket connections through a proxy
+func WithProxy(proxyURL *url.URL) Option {
+	return func(o *options) {
+		o.proxy = proxyURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header sent with every request
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		if o.headers == nil {
+			o.headers = make(http.Header)
+		}
+		o.headers.Add(key, value)
+	}
+}
+
+// WithLogger sets the logger used by the client
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithMetrics sets the metrics receiving request, order and WebSocket measurements
+func WithMetrics(metrics Metrics) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithTracer sets the tracer wrapping every request in a span
+func WithTracer(tracer Tracer) Option {
+	return func(o *options) {
+		o.tracer = tracer
+	}
+}
+
+// WithRetryPolicy sets the retry policy for failed requests
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = &policy
+	}
+}
+
+// WithRateLimiter sets the rate limiter pacing requests; nil disables rate limiting
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *options) {
+		o.rateLimiter = limiter
+		o.noRateLimit = limiter == nil
+	}
+}
+
+// WithCache serves public GET requests through a response cache, e.g.
+// NewResponseCache(DefaultCacheTTLs()). A cache can be shared by several clients.
+func WithCache(cache *ResponseCache) Option {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+// WithCircuitBreaker fails requests fast while their endpoint group's circuit is open, e.g.
+// NewCircuitBreaker(DefaultBreakerConfig()). A breaker can be shared by several clients.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(o *options) {
+		o.breaker = breaker
+	}
+}
+
+// WithFailoverURLs sets alternate base URLs that read-only requests are sent to, in order,
+// when the primary server fails with a server error or timeout
+func WithFailoverURLs(baseURLs ...string) Option {
+	return func(o *options) {
+		o.failover = append(o.failover, baseURLs...)
+	}
+}
+
+// WithDialer sets the WebSocket dialer
+func WithDialer(dialer *websocket.Dialer) Option {
+	return func(o *options) {
+		o.dialer = dialer
+	}
+}
+
+// WithChainConfig sets the chain used for signing, e.g. types.AmoyTestnet
+func WithChainConfig(chainConfig types.ChainConfig) Option {
+	return func(o *options) {
+		o.chainConfig = &chainConfig
+	}
+}
+
+// newOptions applies opts over the defaults
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	if o.logger == nil {
+		o.logger = slog.New(slog.DiscardHandler)
+	}
+	if o.metrics == nil {
+		o.metrics = NopMetrics{}
+	}
+	if o.tracer == nil {
+		o.tracer = nopTracer{}
+	}
+
+	return o
+}
+
+// buildHTTPClient returns the configured HTTP client
+func (o *options) buildHTTPClient() *http.Client {
+	httpClient := &http.Client{Timeout: defaultTimeout}
+	if o.httpClient != nil {
+		clone := *o.httpClient
+		httpClient = &clone
+	}
+
+	if o.transport != nil {
+		httpClient.Transport = o.transport
+	}
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	}
+
+	if o.proxy != nil {
+		transport, ok := httpClient.Transport.(*http.Transport)
+		if httpClient.Transport == nil {
+			transport, ok = http.DefaultTransport.(*http.Transport)
+		}
+		if ok {
+			transport = transport.Clone()
+			transport.Proxy = http.ProxyURL(o.proxy)
+			httpClient.Transport = transport
+		}
+	}
+
+	return httpClient
+}
+
+// buildDialer returns the configured WebSocket dialer
+func (o *options) buildDialer() *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	if o.dialer != nil {
+		dialer = *o.dialer
+	}
+
+	if o.proxy != nil {
+		dialer.Proxy = http.ProxyURL(o.proxy)
+	}
+
+	return &dialer
+}
+
+// buildHeaders returns the default headers sent with every request
+func (o *options) buildHeaders() http.Header {
+	headers := o.headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+
+	if o.userAgent != "" {
+		headers.Set("User-Agent", o.userAgent)
+	}
+
+	return headers
+}
+
+// buildRetryPolicy returns the configured retry policy
+func (o *options) buildRetryPolicy() RetryPolicy {
+	if o.retryPolicy != nil {
+		return *o.retryPolicy
+	}
+	return DefaultRetryPolicy()
+}
+
+// buildRateLimiter returns the configured rate limiter
+func (o *options) buildRateLimiter() *RateLimiter {
+	if o.noRateLimit {
+		return nil
+	}
+	if o.rateLimiter != nil {
+		return o.rateLimiter
+	}
+	return NewRateLimiter(DefaultRateLimits())
+}
+
+// setDefaultHeaders adds the default headers not already set on a request
+func setDefaultHeaders(header, defaults http.Header) {
+	for key, values := range defaults {
+		if _, ok := header[key]; !ok {
+			header[key] = values
+		}
+	}
+}
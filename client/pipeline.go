@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// Request is a single API request flowing through a client's middleware chain
+type Request struct {
+	// Method is the HTTP method
+	Method string
+	// Path is the endpoint path without query string, e.g. "/book"
+	Path string
+	// Query holds the query parameters
+	Query map[string]string
+	// Body is the JSON encoded request body, nil for no body
+	Body []byte
+	// Header holds extra request headers; authentication headers are added per attempt
+	Header http.Header
+	// Auth is the authentication level the request requires
+	Auth AuthLevel
+	// Credentials, if set, sign an L2 request instead of the AuthManager's credentials
+	Credentials *types.APICredentials
+	// Nonce is the nonce signed for L1 authentication
+	Nonce uint64
+	// Timestamp is the unix timestamp signed for L1 authentication; zero uses the auth clock
+	Timestamp int64
+	// Group is the rate limit group of the request
+	Group EndpointGroup
+	// Attempt is the number of the current attempt, starting at 1
+	Attempt int
+}
+
+// clone returns a copy of the request with its own header, so an attempt can be modified freely
+func (r *Request) clone() *Request {
+	clone := *r
+	clone.Header = r.Header.Clone()
+	if clone.Header == nil {
+		clone.Header = make(http.Header)
+	}
+	return &clone
+}
+
+// Handler performs a request and returns the response body
+type Handler func(ctx context.Context, req *Request) ([]byte, error)
+
+// Middleware wraps a Handler with cross-cutting behaviour
+type Middleware func(next Handler) Handler
+
+// chain wraps handler with middlewares; the first middleware is the outermost
+func chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// RetryMiddleware retries failed requests according to policy.
+// Middlewares after it run once per attempt, so every attempt is re-signed.
+func RetryMiddleware(policy RetryPolicy) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			attempt := 0
+			return policy.do(ctx, req.Method, req.Path, func() ([]byte, error) {
+				attempt++
+				attemptReq := req.clone()
+				attemptReq.Attempt = attempt
+				return next(ctx, attemptReq)
+			})
+		}
+	}
+}
+
+// RateLimitMiddleware waits for the rate limit of the request's endpoint group
+func RateLimitMiddleware(limiter *RateLimiter) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			if err := limiter.Wait(ctx, req.Group); err != nil {
+				return nil, fmt.Errorf("failed to wait for rate limit: %w", err)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// AuthMiddleware adds the L1 or L2 authentication headers required by the request
+func AuthMiddleware(authManager *AuthManager) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			var headers map[string]string
+			var err error
+
+			switch req.Auth {
+			case AuthLevelL1:
+				timestamp := req.Timestamp
+				if timestamp == 0 {
+					timestamp = authManager.Now().Unix()
+				}
+				headers, err = authManager.GenerateL1Headers(ctx, strconv.FormatInt(timestamp, 10), req.Nonce)
+				if err != nil {
+					return nil, fmt.Errorf("failed to generate L1 headers: %w", err)
+				}
+			case AuthLevelL2:
+				if req.Credentials != nil {
+					headers, err = authManager.GenerateL2HeadersWith(*req.Credentials, req.Method, req.Path, string(req.Body))
+				} else {
+					headers, err = authManager.GenerateL2Headers(req.Method, req.Path, string(req.Body))
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to generate L2 headers: %w", err)
+				}
+			}
+
+			if len(headers) > 0 {
+				req = req.clone()
+				for key, value := range headers {
+					req.Header.Set(key, value)
+				}
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// transport returns the innermost handler, which sends requests to baseURL with httpClient
+func transport(baseURL string, httpClient *http.Client) Handler {
+	return func(ctx context.Context, req *Request) ([]byte, error) {
+		// Create request
+		var body io.Reader
+		if req.Body != nil {
+			body = bytes.NewReader(req.Body)
+		}
+		httpReq, err := http.NewRequestWithContext(ctx, req.Method, buildURL(baseURL, req.Path, req.Query), body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Set headers
+		for key, values := range req.Header {
+			httpReq.Header[key] = values
+		}
+		if req.Body != nil && httpReq.Header.Get("Content-Type") == "" {
+			httpReq.Header.Set("Content-Type", "application/json")
+		}
+
+		return send(httpClient, httpReq, req.Method, req.Path)
+	}
+}
+
+// send performs a request and returns the response body, or a *types.ClobError for non-2xx responses
+func send(httpClient *http.Client, req *http.Request, method, path string) ([]byte, error) {
+	// Perform request
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, &transportError{op: "perform request", err: err}
+	}
+	defer resp.Body.Close()
+	recordResponse(req.Context(), resp.StatusCode)
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &transportError{op: "read response body", err: err}
+	}
+
+	// Check for errors
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, types.NewHTTPError(method, path, resp, respBody)
+	}
+
+	return respBody, nil
+}
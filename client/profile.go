@@ -0,0 +1,158 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// ProfileEnvPrefix prefixes the environment variables of profiles, e.g.
+// POLYMARKET_PRIVATE_KEY for the default profile or POLYMARKET_MAIN_API_KEY for profile "main"
+const ProfileEnvPrefix = "POLYMARKET_"
+
+// Profile holds the signing key and API credentials of one account
+type Profile struct {
+	// PrivateKey is a hex encoded private key; Keystore takes precedence if both are set
+	PrivateKey string `json:"privateKey,omitempty"`
+	// Keystore is the path of a go-ethereum V3 keystore file
+	Keystore string `json:"keystore,omitempty"`
+	// KeystorePassphrase decrypts the keystore
+	KeystorePassphrase string `json:"keystorePassphrase,omitempty"`
+	// SignatureType is EOA, POLY_PROXY or GNOSIS_SAFE (or 0, 1, 2); empty means EOA
+	SignatureType string `json:"signatureType,omitempty"`
+	// Funder is the proxy wallet holding the funds of POLY_PROXY and GNOSIS_SAFE accounts
+	Funder string `json:"funder,omitempty"`
+
+	APIKey        string `json:"apiKey,omitempty"`
+	APISecret     string `json:"secret,omitempty"`
+	APIPassphrase string `json:"passphrase,omitempty"`
+}
+
+// profileFile is the format of a profile config file
+type profileFile struct {
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// profileEnvVars maps environment variable suffixes to profile fields
+var profileEnvVars = []struct {
+	suffix string
+	field  func(*Profile) *string
+}{
+	{"PRIVATE_KEY", func(p *Profile) *string { return &p.PrivateKey }},
+	{"KEYSTORE", func(p *Profile) *string { return &p.Keystore }},
+	{"KEYSTORE_PASSPHRASE", func(p *Profile) *string { return &p.KeystorePassphrase }},
+	{"SIGNATURE_TYPE", func(p *Profile) *string { return &p.SignatureType }},
+	{"FUNDER", func(p *Profile) *string { return &p.Funder }},
+	{"API_KEY", func(p *Profile) *string { return &p.APIKey }},
+	{"API_SECRET", func(p *Profile) *string { return &p.APISecret }},
+	{"API_PASSPHRASE", func(p *Profile) *string { return &p.APIPassphrase }},
+}
+
+// LoadProfile loads a named profile from a JSON config file of the form
+// {"profiles": {"main": {...}}} and overrides its fields with environment variables,
+// so secrets such as the keystore passphrase can stay out of the file.
+// An empty path loads the profile from the environment only.
+func LoadProfile(path, name string) (*Profile, error) {
+	profile := &Profile{}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read profiles: %w", err)
+		}
+
+		var file profileFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal profiles: %w", err)
+		}
+
+		p, ok := file.Profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("profile %q not found in %s", name, path)
+		}
+		*profile = p
+	}
+
+	profile.applyEnv(name)
+	return profile, nil
+}
+
+// ProfileFromEnv loads a named profile from environment variables, e.g. POLYMARKET_MAIN_KEYSTORE
+// and POLYMARKET_MAIN_KEYSTORE_PASSPHRASE for profile "main". An empty name reads
+// POLYMARKET_KEYSTORE and so on.
+func ProfileFromEnv(name string) *Profile {
+	profile := &Profile{}
+	profile.applyEnv(name)
+	return profile
+}
+
+// applyEnv overrides fields with the profile's environment variables that are set
+func (p *Profile) applyEnv(name string) {
+	prefix := ProfileEnvPrefix
+	if name != "" {
+		prefix += strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name)) + "_"
+	}
+
+	for _, v := range profileEnvVars {
+		if value, ok := os.LookupEnv(prefix + v.suffix); ok {
+			*v.field(p) = value
+		}
+	}
+}
+
+// LogValue implements slog.LogValuer, hiding the profile's secrets
+func (p Profile) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("keystore", p.Keystore),
+		slog.String("signatureType", p.SignatureType),
+		slog.String("funder", p.Funder),
+		slog.String("apiKey", types.MaskAPIKey(p.APIKey)),
+	)
+}
+
+// SetupProfile sets up L1 authentication from the profile's keystore or private key and
+// L2 authentication from its API credentials, whichever are present. API credentials need
+// the address they belong to, so a profile without a key only works once L1 authentication
+// is set up.
+func (am *AuthManager) SetupProfile(profile *Profile) error {
+	signatureType, err := types.ParseSignatureType(profile.SignatureType)
+	if err != nil {
+		return err
+	}
+
+	hasKey := profile.Keystore != "" || profile.PrivateKey != ""
+	hasCreds := profile.APIKey != "" || profile.APISecret != "" || profile.APIPassphrase != ""
+	if !hasKey && !hasCreds {
+		return fmt.Errorf("profile has no private key, keystore or API credentials")
+	}
+	if !hasKey && !am.HasL1Auth() {
+		return fmt.Errorf("profile API credentials require a private key or keystore for their address")
+	}
+
+	switch {
+	case profile.Keystore != "":
+		err = am.SetupL1AuthFromKeystore(profile.Keystore, profile.KeystorePassphrase, signatureType, profile.Funder)
+	case profile.PrivateKey != "":
+		err = am.SetupL1Auth(profile.PrivateKey, signatureType, profile.Funder)
+	}
+	if err != nil {
+		return err
+	}
+
+	if hasCreds {
+		if err := am.SetupL2Auth(profile.APIKey, profile.APISecret, profile.APIPassphrase); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetupProfile sets up authentication from a profile
+func (c *ClobClient) SetupProfile(profile *Profile) error {
+	return c.authManager.SetupProfile(profile)
+}
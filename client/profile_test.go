@@ -0,0 +1,185 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// profileKey and otherProfileKey are well-known development keys, never used on mainnet
+	profileKey           = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	profileAddress       = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	otherProfileKey      = "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+	otherProfileAddress  = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	profileKeystorePass  = "keystore-passphrase"
+	profileAPIPassphrase = "api-passphrase"
+)
+
+// writeProfiles writes a profile config file and returns its path
+func writeProfiles(t *testing.T, config string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeKeystore encrypts privateKey into a keystore file and returns its path
+func writeKeystore(t *testing.T, privateKey, passphrase string) string {
+	t.Helper()
+
+	key, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account.URL.Path
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := writeProfiles(t, `{"profiles": {
+		"main": {"keystore": "/secrets/main.json", "keystorePassphrase": "from-file", "signatureType": "POLY_PROXY", "funder": "0x1", "apiKey": "main-key"},
+		"paper-trading": {"privateKey": "`+profileKey+`"}
+	}}`)
+
+	// Environment variables override the file, and only those of the profile apply
+	t.Setenv("POLYMARKET_MAIN_KEYSTORE_PASSPHRASE", "from-env")
+	t.Setenv("POLYMARKET_MAIN_API_SECRET", "main-secret")
+	t.Setenv("POLYMARKET_PAPER_TRADING_API_KEY", "paper-key")
+	t.Setenv("POLYMARKET_API_KEY", "default-key")
+
+	main, err := LoadProfile(path, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Profile{
+		Keystore:           "/secrets/main.json",
+		KeystorePassphrase: "from-env",
+		SignatureType:      "POLY_PROXY",
+		Funder:             "0x1",
+		APIKey:             "main-key",
+		APISecret:          "main-secret",
+	}
+	if *main != want {
+		t.Errorf("LoadProfile(main) = %+v, want %+v", *main, want)
+	}
+
+	paper, err := LoadProfile(path, "paper-trading")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paper.PrivateKey != profileKey || paper.APIKey != "paper-key" {
+		t.Errorf("LoadProfile(paper-trading) = %+v, want its key and POLYMARKET_PAPER_TRADING_API_KEY", *paper)
+	}
+
+	// Without a file the profile comes from the environment only
+	env, err := LoadProfile("", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *env != (Profile{KeystorePassphrase: "from-env", APISecret: "main-secret"}) {
+		t.Errorf("LoadProfile(\"\", main) = %+v", *env)
+	}
+
+	errorTests := []struct {
+		name    string
+		path    string
+		profile string
+		wantErr string
+	}{
+		{"missing profile", path, "missing", `profile "missing" not found`},
+		{"missing file", filepath.Join(t.TempDir(), "missing.json"), "main", "failed to read profiles"},
+		{"invalid file", writeProfiles(t, `{"profiles": [`), "main", "failed to unmarshal profiles"},
+	}
+
+	for _, tt := range errorTests {
+		_, err := LoadProfile(tt.path, tt.profile)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestProfileFromEnv(t *testing.T) {
+	t.Setenv("POLYMARKET_PRIVATE_KEY", profileKey)
+	t.Setenv("POLYMARKET_SIGNATURE_TYPE", "EOA")
+	t.Setenv("POLYMARKET_MAIN_PRIVATE_KEY", otherProfileKey)
+	t.Setenv("POLYMARKET_MAIN_API_KEY", "main-key")
+
+	if profile := ProfileFromEnv(""); *profile != (Profile{PrivateKey: profileKey, SignatureType: "EOA"}) {
+		t.Errorf("ProfileFromEnv(\"\") = %+v", *profile)
+	}
+	if profile := ProfileFromEnv("main"); *profile != (Profile{PrivateKey: otherProfileKey, APIKey: "main-key"}) {
+		t.Errorf("ProfileFromEnv(main) = %+v", *profile)
+	}
+	if profile := ProfileFromEnv("other"); *profile != (Profile{}) {
+		t.Errorf("ProfileFromEnv(other) = %+v, want an empty profile", *profile)
+	}
+}
+
+func TestSetupProfile(t *testing.T) {
+	keystorePath := writeKeystore(t, profileKey, profileKeystorePass)
+
+	tests := []struct {
+		name        string
+		profile     Profile
+		wantAddress string
+		wantL2      bool
+		wantErr     string
+	}{
+		{"private key", Profile{PrivateKey: profileKey}, profileAddress, false, ""},
+		{"private key and credentials", Profile{PrivateKey: profileKey, APIKey: "key", APISecret: "c2VjcmV0", APIPassphrase: profileAPIPassphrase}, profileAddress, true, ""},
+		{"keystore", Profile{Keystore: keystorePath, KeystorePassphrase: profileKeystorePass}, profileAddress, false, ""},
+		{"keystore before private key", Profile{Keystore: keystorePath, KeystorePassphrase: profileKeystorePass, PrivateKey: otherProfileKey}, profileAddress, false, ""},
+		{"wrong keystore passphrase", Profile{Keystore: keystorePath, KeystorePassphrase: "wrong"}, "", false, "failed to decrypt keystore"},
+		{"credentials only", Profile{APIKey: "key", APISecret: "c2VjcmV0", APIPassphrase: profileAPIPassphrase}, "", false, "require a private key or keystore"},
+		{"partial credentials", Profile{PrivateKey: profileKey, APIKey: "key"}, profileAddress, false, "API credentials cannot be empty"},
+		{"invalid signature type", Profile{PrivateKey: profileKey, SignatureType: "MULTISIG"}, "", false, "signature type"},
+		{"empty", Profile{}, "", false, "profile has no private key, keystore or API credentials"},
+	}
+
+	for _, tt := range tests {
+		am := NewAuthManager()
+		err := am.SetupProfile(&tt.profile)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+		} else if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+
+		if got := am.GetAddress(); got != tt.wantAddress {
+			t.Errorf("%s: address = %q, want %q", tt.name, got, tt.wantAddress)
+		}
+		if am.HasL2Auth() != tt.wantL2 {
+			t.Errorf("%s: L2 authentication = %t, want %t", tt.name, am.HasL2Auth(), tt.wantL2)
+		}
+	}
+
+	// Credentials of a profile without a key can be layered onto existing L1 authentication
+	am := NewAuthManager()
+	if err := am.SetupProfile(&Profile{PrivateKey: otherProfileKey}); err != nil {
+		t.Fatal(err)
+	}
+	if err := am.SetupProfile(&Profile{APIKey: "key", APISecret: "c2VjcmV0", APIPassphrase: profileAPIPassphrase}); err != nil {
+		t.Fatal(err)
+	}
+	headers, err := am.GenerateL2Headers("GET", "/data/orders", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers["POLY_ADDRESS"] != otherProfileAddress {
+		t.Errorf("POLY_ADDRESS = %q, want %q", headers["POLY_ADDRESS"], otherProfileAddress)
+	}
+}
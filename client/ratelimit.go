@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointGroup identifies a rate limit budget shared by a set of endpoints
+type EndpointGroup string
+
+const (
+	// GroupMarketData - Public CLOB market data (/book, /price, /prices-history, ...)
+	GroupMarketData EndpointGroup = "market_data"
+	// GroupOrders - Order placement (POST /order, POST /orders)
+	GroupOrders EndpointGroup = "orders"
+	// GroupCancels - Order cancellation (DELETE /order, /orders, /cancel-all, ...)
+	GroupCancels EndpointGroup = "cancels"
+	// GroupDefault - All other CLOB endpoints
+	GroupDefault EndpointGroup = "default"
+	// GroupGamma - Gamma API endpoints
+	GroupGamma EndpointGroup = "gamma"
+)
+
+// marketDataPaths are the public CLOB market data endpoints
+var marketDataPaths = map[string]bool{
+	"/book":               true,
+	"/books":              true,
+	"/price":              true,
+	"/prices":             true,
+	"/midpoint":           true,
+	"/midpoints":          true,
+	"/prices-history":     true,
+	"/spread":             true,
+	"/spreads":            true,
+	"/last-trade-price":   true,
+	"/last-trades-prices": true,
+	"/tick-size":          true,
+	"/neg-risk":           true,
+}
+
+// clobEndpointGroup returns the rate limit group of a CLOB request
+func clobEndpointGroup(method, path string) EndpointGroup {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	switch {
+	case method == http.MethodDelete:
+		return GroupCancels
+	case method == http.MethodPost && (path == "/order" || path == "/orders"):
+		return GroupOrders
+	case marketDataPaths[path]:
+		return GroupMarketData
+	default:
+		return GroupDefault
+	}
+}
+
+// gammaEndpointGroup returns the rate limit group of a Gamma request
+func gammaEndpointGroup(method, path string) EndpointGroup {
+	return GroupGamma
+}
+
+// RateLimit configures a token bucket
+type RateLimit struct {
+	// Rate is the number of requests allowed per second; zero disables the limit
+	Rate float64
+	// Burst is the number of requests that can be made at once
+	Burst int
+}
+
+// DefaultRateLimits returns conservative limits below the published Polymarket API limits
+func DefaultRateLimits() map[EndpointGroup]RateLimit {
+	return map[EndpointGroup]RateLimit{
+		GroupMarketData: {Rate: 15, Burst: 30},
+		GroupOrders:     {Rate: 40, Burst: 200},
+		GroupCancels:    {Rate: 40, Burst: 200},
+		GroupDefault:    {Rate: 50, Burst: 100},
+		GroupGamma:      {Rate: 10, Burst: 20},
+	}
+}
+
+// RateLimiter paces requests with one token bucket per endpoint group.
+// It is safe for concurrent use and can be shared by several clients.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[EndpointGroup]*tokenBucket
+}
+
+// NewRateLimiter creates a rate limiter with the given limits; groups without a limit are not paced
+func NewRateLimiter(limits map[EndpointGroup]RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		buckets: make(map[EndpointGroup]*tokenBucket),
+	}
+	for group, limit := range limits {
+		l.SetLimit(group, limit)
+	}
+	return l
+}
+
+// SetLimit sets or replaces the limit of an endpoint group
+func (l *RateLimiter) SetLimit(group EndpointGroup, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limit.Rate <= 0 {
+		delete(l.buckets, group)
+		return
+	}
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	l.buckets[group] = &tokenBucket{
+		rate:   limit.Rate,
+		burst:  float64(limit.Burst),
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request of the group may be made or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context, group EndpointGroup) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	bucket := l.buckets[group]
+	l.mu.Unlock()
+
+	if bucket == nil {
+		return nil
+	}
+	return bucket.wait(ctx)
+}
+
+// tokenBucket is a single token bucket
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// wait takes a token, sleeping until one is available
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
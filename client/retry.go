@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// RetryPolicy configures automatic retries of failed requests
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first; values below 2 disable retries
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled on every further retry
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff delay
+	MaxDelay time.Duration
+	// Jitter randomizes each delay by up to this fraction (0 to 1)
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// NoRetryPolicy returns a policy that never retries
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// replaySafePosts are POST endpoints that can be sent again without side effects
+var replaySafePosts = map[string]bool{
+	"/books":              true,
+	"/prices":             true,
+	"/spreads":            true,
+	"/midpoints":          true,
+	"/orders-scoring":     true,
+	"/last-trades-prices": true,
+}
+
+// orderPosts are POST endpoints that place orders. After a server error or timeout it is unknown
+// whether the order reached the matching engine, and a replay would be rejected as
+// INVALID_ORDER_DUPLICATED although the order is live, so they are only retried when the
+// server certainly did not process the request.
+var orderPosts = map[string]bool{
+	"/order":  true,
+	"/orders": true,
+}
+
+// isReplaySafe reports whether a request may be retried after any transient failure
+func isReplaySafe(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return replaySafePosts[path]
+	default:
+		return false
+	}
+}
+
+// isOrderPost reports whether a request places orders
+func isOrderPost(method, path string) bool {
+	return method == http.MethodPost && orderPosts[path]
+}
+
+// unprocessed reports whether a failed request certainly was not processed by the server:
+// it was rate limited or the connection could not be established
+func unprocessed(err error) bool {
+	if clobErr, ok := types.AsClobError(err); ok {
+		return clobErr.Code == types.ErrRateLimited
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// transportError marks failures to reach the server, which are retryable
+type transportError struct {
+	op  string
+	err error
+}
+
+func (e *transportError) Error() string {
+	return fmt.Sprintf("failed to %s: %v", e.op, e.err)
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// do runs attempt until it succeeds, the error is not retryable or the attempts are exhausted.
+// Every attempt must rebuild and re-sign its request but send the same body.
+func (p RetryPolicy) do(ctx context.Context, method, path string, attempt func() ([]byte, error)) ([]byte, error) {
+	replaySafe := isReplaySafe(method, path)
+	orderPost := isOrderPost(method, path)
+
+	for i := 1; ; i++ {
+		body, err := attempt()
+		if err == nil {
+			return body, nil
+		}
+
+		retryAfter, retryable := retryableError(ctx, err)
+		replayable := replaySafe || orderPost && unprocessed(err)
+		if !retryable || !replayable || i >= p.MaxAttempts {
+			return nil, err
+		}
+
+		timer := time.NewTimer(p.backoff(i, retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before retry number attempt, honouring the server's Retry-After
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(delay))
+	}
+
+	if retryAfter > delay {
+		return retryAfter
+	}
+	return delay
+}
+
+// retryableError reports whether an error is transient and the delay requested by the server
+func retryableError(ctx context.Context, err error) (time.Duration, bool) {
+	if ctx.Err() != nil {
+		return 0, false
+	}
+
+	if clobErr, ok := types.AsClobError(err); ok {
+		return clobErr.RetryAfter, clobErr.IsRetryable()
+	}
+
+	var tErr *transportError
+	if errors.As(err, &tErr) {
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy retries quickly so tests do not wait for backoff
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+func TestRetryOrderPosts(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		status   int
+		attempts int32
+	}{
+		{"order post on server error", http.MethodPost, "/order", http.StatusInternalServerError, 1},
+		{"batch order post on server error", http.MethodPost, "/orders", http.StatusServiceUnavailable, 1},
+		{"order post when rate limited", http.MethodPost, "/order", http.StatusTooManyRequests, 3},
+		{"read-only post on server error", http.MethodPost, "/books", http.StatusInternalServerError, 3},
+		{"get on server error", http.MethodGet, "/book", http.StatusInternalServerError, 3},
+		{"key creation on server error", http.MethodPost, "/auth/api-key", http.StatusInternalServerError, 1},
+	}
+
+	for _, tt := range tests {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(tt.status)
+		}))
+
+		c := NewClobClient(server.URL, WithRetryPolicy(testRetryPolicy), WithRateLimiter(nil))
+		_, err := c.Execute(context.Background(), &Request{Method: tt.method, Path: tt.path, Body: []byte(`{}`)})
+		server.Close()
+
+		if err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+		if got := attempts.Load(); got != tt.attempts {
+			t.Errorf("%s: %d attempts, want %d", tt.name, got, tt.attempts)
+		}
+	}
+}
+
+func TestRetryOrderPostTimeout(t *testing.T) {
+	var attempts atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := NewClobClient(server.URL, WithRetryPolicy(testRetryPolicy), WithRateLimiter(nil), WithTimeout(50*time.Millisecond))
+	if _, err := c.Execute(context.Background(), &Request{Method: http.MethodPost, Path: "/order", Body: []byte(`{}`)}); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("%d attempts of a timed out order post, want 1", got)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryOrderPostConnectionRefused(t *testing.T) {
+	var attempts atomic.Int32
+	refuse := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	})
+
+	c := NewClobClient("http://clob.invalid", WithRetryPolicy(testRetryPolicy), WithRateLimiter(nil), WithTransport(refuse))
+	if _, err := c.Execute(context.Background(), &Request{Method: http.MethodPost, Path: "/order", Body: []byte(`{}`)}); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("%d attempts of an order post that never connected, want 3", got)
+	}
+}
@@ -3,8 +3,11 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -16,10 +19,19 @@ type WebSocketClient struct {
 	baseURL      string
 	conn         *websocket.Conn
 	authManager  *AuthManager
+	dialer       *websocket.Dialer
+	headers      http.Header
+	logger       *slog.Logger
+	metrics      Metrics
+	connected    bool
 	pingInterval time.Duration
-	pingTicker   *time.Ticker
 	writeMutex   sync.Mutex
 	stopChan     chan struct{}
+	stopRotation func()
+	// reauthMutex serialises connecting the user channel and re-authenticating it
+	reauthMutex sync.Mutex
+	// rotation counts credential rotations; a re-authentication for an older one is stale
+	rotation atomic.Uint64
 
 	// Event handlers
 	onBookMessage           func(*types.WebSocketBookEvent)
@@ -34,14 +46,19 @@ type WebSocketClient struct {
 }
 
 // NewWebSocketClient creates a new WebSocket client
-func NewWebSocketClient(baseURL string, authManager *AuthManager) *WebSocketClient {
+func NewWebSocketClient(baseURL string, authManager *AuthManager, opts ...Option) *WebSocketClient {
 	if baseURL == "" {
 		baseURL = "wss://ws-subscriptions-clob.polymarket.com"
 	}
 
+	o := newOptions(opts)
 	return &WebSocketClient{
 		baseURL:      baseURL,
 		authManager:  authManager,
+		dialer:       o.buildDialer(),
+		headers:      o.buildHeaders(),
+		logger:       o.logger,
+		metrics:      o.metrics,
 		pingInterval: 10 * time.Second,
 		stopChan:     make(chan struct{}),
 	}
@@ -104,12 +121,14 @@ func (w *WebSocketClient) ConnectMarketChannel(assetIDs []string, customFeatureE
 		return fmt.Errorf("failed to parse WebSocket URL: %w", err)
 	}
 
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	conn, _, err := w.dialer.Dial(u.String(), w.headers)
 	if err != nil {
 		return fmt.Errorf("failed to connect to WebSocket: %w", err)
 	}
 
+	w.writeMutex.Lock()
 	w.conn = conn
+	w.writeMutex.Unlock()
 
 	// Send subscription message
 	subscribeMsg := types.WebSocketSubscribeRequest{
@@ -122,9 +141,12 @@ func (w *WebSocketClient) ConnectMarketChannel(assetIDs []string, customFeatureE
 		conn.Close()
 		return fmt.Errorf("failed to send subscription message: %w", err)
 	}
+	w.logger.Info("websocket connected", "channel", subscribeMsg.Type, "assets", len(assetIDs))
+	w.metrics.ObserveWebSocketConnect(subscribeMsg.Type, w.connected)
+	w.connected = true
 
 	// Start message handler
-	go w.messageHandler()
+	go w.messageHandler(conn, subscribeMsg.Type)
 
 	// Start ping routine
 	go w.startPing()
@@ -132,32 +154,88 @@ func (w *WebSocketClient) ConnectMarketChannel(assetIDs []string, customFeatureE
 	return nil
 }
 
-// Connect connects to the user channel
+// Connect connects to the user channel.
+// When the AuthManager's credentials are rotated, the channel reconnects with the new
+// credentials; events may be delivered twice while both connections are open. When they are
+// cleared, e.g. by DeleteAPIKey, the channel is closed and the error handler is notified;
+// installing new credentials reconnects it.
 func (w *WebSocketClient) ConnectUserChannel(markets []string) error {
 	// Validate L2 authentication
 	if !w.authManager.HasL2Auth() {
 		return fmt.Errorf("L2 authentication required for user channel")
 	}
 
+	w.reauthMutex.Lock()
+	defer w.reauthMutex.Unlock()
+
+	// Re-authenticate when the credentials are rotated. Rotations pending for an earlier
+	// connection are stale, and rotations from here on wait for this connection.
+	w.rotation.Add(1)
+	stopRotated := w.authManager.OnCredentialsRotated(func(types.APICredentials) {
+		go w.reauthenticate(markets, w.rotation.Add(1))
+	})
+	stopCleared := w.authManager.OnCredentialsCleared(func() {
+		go w.closeUserChannel(w.rotation.Add(1))
+	})
+	stopRotation := func() {
+		stopRotated()
+		stopCleared()
+	}
+
 	creds := w.authManager.GetAPICredentials()
 	if creds == nil {
+		stopRotation()
 		return fmt.Errorf("API credentials not found")
 	}
 
-	u, err := url.Parse(w.baseURL + "/ws/user")
+	conn, subscribeMsg, err := w.dialUserChannel(markets, *creds)
 	if err != nil {
-		return fmt.Errorf("failed to parse WebSocket URL: %w", err)
+		stopRotation()
+		return err
 	}
 
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	w.writeMutex.Lock()
+	previousConn := w.conn
+	w.conn = conn
+	previousRotation := w.stopRotation
+	w.stopRotation = stopRotation
+	w.writeMutex.Unlock()
+
+	// A reconnect replaces the previous user channel connection
+	if previousRotation != nil {
+		previousRotation()
+	}
+	if previousConn != nil {
+		previousConn.Close()
+	}
+
+	w.logger.Info("websocket connected", "channel", subscribeMsg.Type, "markets", len(markets), "auth", subscribeMsg.Auth)
+	w.metrics.ObserveWebSocketConnect(subscribeMsg.Type, w.connected)
+	w.connected = true
+
+	// Start message handler
+	go w.messageHandler(conn, subscribeMsg.Type)
+
+	// Start ping routine
+	go w.startPing()
+
+	return nil
+}
+
+// dialUserChannel opens a user channel connection authenticated with creds
+func (w *WebSocketClient) dialUserChannel(markets []string, creds types.APICredentials) (*websocket.Conn, *types.WebSocketSubscribeRequest, error) {
+	u, err := url.Parse(w.baseURL + "/ws/user")
 	if err != nil {
-		return fmt.Errorf("failed to connect to WebSocket: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse WebSocket URL: %w", err)
 	}
 
-	w.conn = conn
+	conn, _, err := w.dialer.Dial(u.String(), w.headers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to WebSocket: %w", err)
+	}
 
 	// Send subscription message with authentication
-	subscribeMsg := types.WebSocketSubscribeRequest{
+	subscribeMsg := &types.WebSocketSubscribeRequest{
 		Auth: &types.WebSocketAuth{
 			APIKey:     creds.APIKey,
 			Secret:     creds.Secret,
@@ -167,58 +245,148 @@ func (w *WebSocketClient) ConnectUserChannel(markets []string) error {
 		Type:    types.WSChannelUser,
 	}
 
-	if err := w.writeMessage(subscribeMsg); err != nil {
+	if err := writeTo(conn, subscribeMsg); err != nil {
 		conn.Close()
-		return fmt.Errorf("failed to send subscription message: %w", err)
+		return nil, nil, fmt.Errorf("failed to send subscription message: %w", err)
 	}
 
-	// Start message handler
-	go w.messageHandler()
+	return conn, subscribeMsg, nil
+}
 
-	// Start ping routine
-	go w.startPing()
+// reauthenticate replaces the user channel connection with one authenticated with the current
+// credentials after rotation number generation, unless a later rotation superseded it. The old
+// connection is closed once the new one is subscribed, so no events are missed.
+// Re-authentications run one at a time, so the connection ends up authenticated with the
+// latest credentials.
+func (w *WebSocketClient) reauthenticate(markets []string, generation uint64) {
+	w.reauthMutex.Lock()
+	defer w.reauthMutex.Unlock()
 
-	return nil
+	if w.rotation.Load() != generation {
+		return
+	}
+	select {
+	case <-w.stopChan:
+		return
+	default:
+	}
+
+	creds := w.authManager.GetAPICredentials()
+	if creds == nil {
+		return
+	}
+
+	conn, subscribeMsg, err := w.dialUserChannel(markets, *creds)
+	if err != nil {
+		w.logger.Warn("websocket re-authentication failed", "channel", types.WSChannelUser, "error", err)
+		if w.onError != nil {
+			w.onError(fmt.Errorf("failed to re-authenticate user channel: %w", err))
+		}
+		return
+	}
+	if w.rotation.Load() != generation {
+		conn.Close()
+		return
+	}
+
+	// Close closes stopChan before taking writeMutex, so either it closes the new connection
+	// or the client was closed while dialing and the new connection is dropped here
+	w.writeMutex.Lock()
+	select {
+	case <-w.stopChan:
+		w.writeMutex.Unlock()
+		conn.Close()
+		return
+	default:
+	}
+	old := w.conn
+	w.conn = conn
+	w.writeMutex.Unlock()
+
+	if old != nil {
+		old.Close()
+	}
+	w.logger.Info("websocket re-authenticated", "channel", subscribeMsg.Type, "auth", subscribeMsg.Auth)
+	w.metrics.ObserveWebSocketConnect(subscribeMsg.Type, true)
+
+	go w.messageHandler(conn, subscribeMsg.Type)
 }
 
-// SubscribeToAssets subscribes to additional asset IDs (market channel only)
-func (w *WebSocketClient) SubscribeToAssets(assetIDs []string) error {
-	if w.conn == nil {
-		return fmt.Errorf("WebSocket connection not established")
+// closeUserChannel closes the user channel connection after its credentials were cleared in
+// rotation number generation, unless a later rotation superseded it
+func (w *WebSocketClient) closeUserChannel(generation uint64) {
+	w.reauthMutex.Lock()
+	defer w.reauthMutex.Unlock()
+
+	if w.rotation.Load() != generation {
+		return
+	}
+	select {
+	case <-w.stopChan:
+		return
+	default:
+	}
+
+	// Detach the connection first, so its message handler ends silently
+	w.writeMutex.Lock()
+	conn := w.conn
+	w.conn = nil
+	w.writeMutex.Unlock()
+
+	if conn == nil {
+		return
 	}
+	conn.Close()
 
+	w.logger.Warn("websocket closed", "channel", types.WSChannelUser, "reason", "API credentials cleared")
+	if w.onError != nil {
+		w.onError(fmt.Errorf("user channel closed: API credentials were cleared"))
+	}
+	if w.onClose != nil {
+		w.onClose()
+	}
+}
+
+// SubscribeToAssets subscribes to additional asset IDs (market channel only)
+func (w *WebSocketClient) SubscribeToAssets(assetIDs []string) error {
 	updateMsg := types.WebSocketSubscribeUpdate{
 		AssetIDs:  assetIDs,
 		Operation: "subscribe",
 	}
 
+	w.logger.Info("websocket subscription changed", "operation", updateMsg.Operation, "assets", len(assetIDs))
 	return w.writeMessage(updateMsg)
 }
 
 // UnsubscribeFromAssets unsubscribes from asset IDs (market channel only)
 func (w *WebSocketClient) UnsubscribeFromAssets(assetIDs []string) error {
-	if w.conn == nil {
-		return fmt.Errorf("WebSocket connection not established")
-	}
-
 	updateMsg := types.WebSocketSubscribeUpdate{
 		AssetIDs:  assetIDs,
 		Operation: "unsubscribe",
 	}
 
+	w.logger.Info("websocket subscription changed", "operation", updateMsg.Operation, "assets", len(assetIDs))
 	return w.writeMessage(updateMsg)
 }
 
 // Close closes the WebSocket connection
 func (w *WebSocketClient) Close() error {
 	close(w.stopChan)
+	w.logger.Info("websocket closed")
+
+	// The ping routine stops its ticker on stopChan
+	w.writeMutex.Lock()
+	conn := w.conn
+	stopRotation := w.stopRotation
+	w.stopRotation = nil
+	w.writeMutex.Unlock()
 
-	if w.pingTicker != nil {
-		w.pingTicker.Stop()
+	if stopRotation != nil {
+		stopRotation()
 	}
 
-	if w.conn != nil {
-		return w.conn.Close()
+	if conn != nil {
+		return conn.Close()
 	}
 
 	return nil
@@ -233,43 +401,57 @@ func (w *WebSocketClient) writeMessage(msg interface{}) error {
 		return fmt.Errorf("WebSocket connection not established")
 	}
 
+	return writeTo(w.conn, msg)
+}
+
+// writeTo writes a message to conn; callers must serialise writes
+func writeTo(conn *websocket.Conn, msg interface{}) error {
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	w.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-	if err := w.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+	conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
 		return fmt.Errorf("failed to write message: %w", err)
 	}
 
 	return nil
 }
 
+// replaced reports whether conn was replaced by re-authentication
+func (w *WebSocketClient) replaced(conn *websocket.Conn) bool {
+	w.writeMutex.Lock()
+	defer w.writeMutex.Unlock()
+
+	return w.conn != conn
+}
+
 // startPing starts the ping routine
 func (w *WebSocketClient) startPing() {
-	w.pingTicker = time.NewTicker(w.pingInterval)
-	defer w.pingTicker.Stop()
+	pingTicker := time.NewTicker(w.pingInterval)
+	defer pingTicker.Stop()
 
 	for {
 		select {
 		case <-w.stopChan:
 			return
-		case <-w.pingTicker.C:
+		case <-pingTicker.C:
+			w.writeMutex.Lock()
 			if w.conn != nil {
-				w.writeMutex.Lock()
 				w.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
 				w.conn.WriteMessage(websocket.TextMessage, []byte("PING"))
-				w.writeMutex.Unlock()
 			}
+			w.writeMutex.Unlock()
 		}
 	}
 }
 
-// messageHandler handles incoming WebSocket messages
-func (w *WebSocketClient) messageHandler() {
+// messageHandler handles incoming WebSocket messages of conn until it is closed
+func (w *WebSocketClient) messageHandler(conn *websocket.Conn, channel types.WebSocketChannelType) {
 	defer func() {
-		if w.onClose != nil {
+		// A connection replaced on re-authentication ends silently
+		if w.onClose != nil && !w.replaced(conn) {
 			w.onClose()
 		}
 	}()
@@ -279,12 +461,12 @@ func (w *WebSocketClient) messageHandler() {
 		case <-w.stopChan:
 			return
 		default:
-			if w.conn == nil {
-				return
-			}
-
-			_, message, err := w.conn.ReadMessage()
+			_, message, err := conn.ReadMessage()
 			if err != nil {
+				if w.replaced(conn) {
+					return
+				}
+				w.logger.Warn("websocket read failed", "error", err)
 				if w.onError != nil {
 					w.onError(fmt.Errorf("WebSocket read error: %w", err))
 				}
@@ -296,13 +478,13 @@ func (w *WebSocketClient) messageHandler() {
 				continue
 			}
 
-			w.handleMessage(message)
+			w.handleMessage(channel, message)
 		}
 	}
 }
 
 // handleMessage processes incoming WebSocket messages
-func (w *WebSocketClient) handleMessage(message []byte) {
+func (w *WebSocketClient) handleMessage(channel types.WebSocketChannelType, message []byte) {
 	var baseMsg map[string]interface{}
 	if err := json.Unmarshal(message, &baseMsg); err != nil {
 		if w.onError != nil {
@@ -319,6 +501,7 @@ func (w *WebSocketClient) handleMessage(message []byte) {
 		return
 	}
 
+	start := time.Now()
 	switch types.WebSocketEventType(eventType) {
 	case types.WSEventTypeBook:
 		w.handleBookMessage(message)
@@ -338,7 +521,9 @@ func (w *WebSocketClient) handleMessage(message []byte) {
 		if w.onError != nil {
 			w.onError(fmt.Errorf("unknown event type: %s", eventType))
 		}
+		return
 	}
+	w.metrics.ObserveWebSocketMessage(channel, types.WebSocketEventType(eventType), time.Since(start))
 }
 
 func (w *WebSocketClient) handleBookMessage(message []byte) {
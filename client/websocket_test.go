@@ -0,0 +1,238 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// userChannelServer accepts user channel connections and tracks the API key each open one
+// subscribed with
+type userChannelServer struct {
+	mu         sync.Mutex
+	open       map[*websocket.Conn]string
+	subscribed int
+}
+
+func (s *userChannelServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var subscribe types.WebSocketSubscribeRequest
+	if err := conn.ReadJSON(&subscribe); err != nil || subscribe.Auth == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.open[conn] = subscribe.Auth.APIKey
+	s.subscribed++
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.open, conn)
+		s.mu.Unlock()
+	}()
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// keys returns the API keys of the open connections
+func (s *userChannelServer) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.open))
+	for _, key := range s.open {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestUserChannelReauthentication(t *testing.T) {
+	channel := &userChannelServer{open: make(map[*websocket.Conn]string)}
+	server := httptest.NewServer(channel)
+	defer server.Close()
+
+	am := NewAuthManager()
+	if err := am.SetupL2Auth("key-0", "secret", "passphrase"); err != nil {
+		t.Fatal(err)
+	}
+
+	ws := NewWebSocketClient("ws"+strings.TrimPrefix(server.URL, "http"), am)
+	ws.SetPingInterval(time.Millisecond)
+	defer ws.Close()
+
+	// Reconnecting replaces the rotation listener of the previous connection
+	for i := 0; i < 2; i++ {
+		if err := ws.ConnectUserChannel([]string{"0x1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	am.mu.Lock()
+	listeners := len(am.listeners)
+	am.mu.Unlock()
+	if listeners != 1 {
+		t.Fatalf("%d rotation listeners after reconnecting, want 1", listeners)
+	}
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			am.RotateAPICredentials(types.APICredentials{APIKey: fmt.Sprintf("key-%d", i), Secret: "secret", Passphrase: "passphrase"})
+		}()
+	}
+	wg.Wait()
+	last := am.GetAPICredentials().APIKey
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		keys := channel.keys()
+		if len(keys) == 1 && keys[0] == last {
+			break
+		}
+		if time.Now().After(deadline) {
+			data, _ := json.Marshal(keys)
+			t.Fatalf("open connections authenticated with %s, want only %s", data, last)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestUserChannelClosedWhenCredentialsCleared(t *testing.T) {
+	tests := []struct {
+		name  string
+		clear func(am *AuthManager) error
+	}{
+		{"cleared", func(am *AuthManager) error {
+			am.ClearAPICredentials()
+			return nil
+		}},
+		{"account switched", func(am *AuthManager) error {
+			return am.SetupL1Auth("59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d", types.EOA, "")
+		}},
+	}
+
+	for _, tt := range tests {
+		channel := &userChannelServer{open: make(map[*websocket.Conn]string)}
+		server := httptest.NewServer(channel)
+
+		am := NewAuthManager()
+		if err := am.SetupL1Auth("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", types.EOA, ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := am.SetupL2Auth("key-0", "secret", "passphrase"); err != nil {
+			t.Fatal(err)
+		}
+
+		ws := NewWebSocketClient("ws"+strings.TrimPrefix(server.URL, "http"), am)
+		errs := make(chan error, 1)
+		ws.SetErrorHandler(func(err error) { errs <- err })
+		if err := ws.ConnectUserChannel([]string{"0x1"}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := tt.clear(am); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-errs:
+		case <-time.After(5 * time.Second):
+			t.Errorf("%s: error handler not notified", tt.name)
+		}
+		waitForKeys(t, channel)
+
+		// Installing new credentials reconnects the channel
+		if err := am.SetupL2Auth("key-1", "secret", "passphrase"); err != nil {
+			t.Fatal(err)
+		}
+		waitForKeys(t, channel, "key-1")
+
+		ws.Close()
+		server.Close()
+	}
+}
+
+// waitForKeys waits until the open connections of channel are authenticated with keys
+func waitForKeys(t *testing.T, channel *userChannelServer, keys ...string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		open := channel.keys()
+		if strings.Join(open, ",") == strings.Join(keys, ",") {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("open connections authenticated with %v, want %v", open, keys)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestUserChannelCloseDuringReauthentication(t *testing.T) {
+	channel := &userChannelServer{open: make(map[*websocket.Conn]string)}
+	var block atomic.Bool
+	dialing := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if block.Load() {
+			dialing <- struct{}{}
+			<-release
+		}
+		channel.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	am := NewAuthManager()
+	if err := am.SetupL2Auth("key-0", "secret", "passphrase"); err != nil {
+		t.Fatal(err)
+	}
+
+	ws := NewWebSocketClient("ws"+strings.TrimPrefix(server.URL, "http"), am)
+	if err := ws.ConnectUserChannel([]string{"0x1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Close the client while the re-authentication is dialing
+	block.Store(true)
+	if err := am.RotateAPICredentials(types.APICredentials{APIKey: "key-1", Secret: "secret", Passphrase: "passphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	<-dialing
+	ws.Close()
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		channel.mu.Lock()
+		subscribed := channel.subscribed
+		channel.mu.Unlock()
+		if subscribed == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("re-authentication did not subscribe")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	waitForKeys(t, channel)
+}
@@ -1,11 +1,10 @@
 package crypto
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"crypto/rand"
 	"fmt"
 	"math/big"
-	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -16,45 +15,44 @@ import (
 
 // EIP712Signer handles EIP-712 signature generation
 type EIP712Signer struct {
-	privateKey *ecdsa.PrivateKey
-	address    common.Address
-	chainID    int64
+	signer  Signer
+	chainID int64
 }
 
 // NewEIP712Signer creates a new EIP-712 signer
 func NewEIP712Signer(privateKeyHex string, chainID int64) (*EIP712Signer, error) {
-	if privateKeyHex == "" {
-		return nil, fmt.Errorf("private key cannot be empty")
-	}
-
-	// Remove 0x prefix if present
-	privateKeyHex = strings.TrimPrefix(privateKeyHex, "0x")
-
-	// Convert hex to private key
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	signer, err := NewPrivateKeySigner(privateKeyHex)
 	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
+		return nil, err
 	}
 
-	// Get address from private key
-	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	return NewEIP712SignerWithSigner(signer, chainID), nil
+}
 
+// NewEIP712SignerWithSigner creates a new EIP-712 signer backed by the given Signer
+func NewEIP712SignerWithSigner(signer Signer, chainID int64) *EIP712Signer {
 	return &EIP712Signer{
-		privateKey: privateKey,
-		address:    address,
-		chainID:    chainID,
-	}, nil
+		signer:  signer,
+		chainID: chainID,
+	}
 }
 
 // GetAddress returns the signer's address
 func (s *EIP712Signer) GetAddress() string {
-	return s.address.Hex()
+	return s.signer.Address().Hex()
 }
 
-// SignClobAuth signs a CLOB authentication message
-func (s *EIP712Signer) SignClobAuth(timestamp string, nonce uint64) (string, error) {
-	// Create EIP-712 typed data
-	typedData := apitypes.TypedData{
+// GetChainID returns the chain ID used in signing domains
+func (s *EIP712Signer) GetChainID() int64 {
+	return s.chainID
+}
+
+// ClobAuthMessage is the statement signed in ClobAuth messages
+const ClobAuthMessage = "This message attests that I control the given wallet"
+
+// BuildClobAuthTypedData builds the EIP-712 typed data of a CLOB authentication message
+func BuildClobAuthTypedData(address, timestamp string, nonce uint64, chainID int64) apitypes.TypedData {
+	return apitypes.TypedData{
 		Types: apitypes.Types{
 			"EIP712Domain": {
 				{Name: "name", Type: "string"},
@@ -72,17 +70,22 @@ func (s *EIP712Signer) SignClobAuth(timestamp string, nonce uint64) (string, err
 		Domain: apitypes.TypedDataDomain{
 			Name:    "ClobAuthDomain",
 			Version: "1",
-			ChainId: math.NewHexOrDecimal256(137),
+			ChainId: math.NewHexOrDecimal256(chainID),
 		},
 		Message: apitypes.TypedDataMessage{
-			"address":   s.address.Hex(),
+			"address":   address,
 			"timestamp": timestamp,
 			"nonce":     fmt.Sprintf("%d", nonce),
-			"message":   "This message attests that I control the given wallet",
+			"message":   ClobAuthMessage,
 		},
 	}
+}
 
-	sig, err := s.SignTypedData(typedData)
+// SignClobAuth signs a CLOB authentication message
+func (s *EIP712Signer) SignClobAuth(ctx context.Context, timestamp string, nonce uint64) (string, error) {
+	typedData := BuildClobAuthTypedData(s.GetAddress(), timestamp, nonce, s.chainID)
+
+	sig, err := s.SignTypedData(ctx, typedData)
 	if err != nil {
 		return "", err
 	}
@@ -91,23 +94,34 @@ func (s *EIP712Signer) SignClobAuth(timestamp string, nonce uint64) (string, err
 }
 
 // SignTypedData signs typed data and returns the signature
-func (s *EIP712Signer) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+func (s *EIP712Signer) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
 	hash, err := s.EncodeForSigning(typedData)
 	if err != nil {
 		return nil, err
 	}
 
-	sig, err := crypto.Sign(hash.Bytes(), s.privateKey)
+	sig, err := s.signer.SignHash(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
 
-	sig[64] += 27
+	// Transform V from 0/1 to yellow paper 27/28
+	if sig[crypto.RecoveryIDOffset] < 27 {
+		sig[crypto.RecoveryIDOffset] += 27
+	}
 	return sig, nil
 }
 
 // EncodeForSigning encodes the typed data for signing
 func (s *EIP712Signer) EncodeForSigning(typedData apitypes.TypedData) (common.Hash, error) {
+	return HashTypedData(typedData)
+}
+
+// HashTypedData returns the EIP-712 digest of typed data
+func HashTypedData(typedData apitypes.TypedData) (common.Hash, error) {
 	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
 	if err != nil {
 		return common.Hash{}, err
@@ -125,25 +139,19 @@ func (s *EIP712Signer) EncodeForSigning(typedData apitypes.TypedData) (common.Ha
 
 // VerifySig verifies signature with recovered address
 func VerifySig(from, sigHex string, msg []byte) bool {
-	sig := hexutil.MustDecode(sigHex)
-
-	if sig[crypto.RecoveryIDOffset] == 27 || sig[crypto.RecoveryIDOffset] == 28 {
-		sig[crypto.RecoveryIDOffset] -= 27 // Transform yellow paper V from 27/28 to 0/1
-	}
-
-	recovered, err := crypto.SigToPub(msg, sig)
-	if err != nil {
+	if len(msg) != common.HashLength {
 		return false
 	}
-
-	recoveredAddr := crypto.PubkeyToAddress(*recovered)
-	fmt.Printf("the recovered address: %v \n", recoveredAddr)
-	return from == recoveredAddr.Hex()
+	return VerifySignature(from, sigHex, common.BytesToHash(msg)) == nil
 }
 
-// GenerateSalt generates a random salt for orders
+// maxSalt bounds order salts to integers that JSON numbers and int64 represent exactly, as the
+// official order utilities do
+const maxSalt = 1 << 53
+
+// GenerateSalt generates a random salt for orders below 2^53
 func GenerateSalt() (uint64, error) {
-	salt, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	salt, err := rand.Int(rand.Reader, big.NewInt(maxSalt))
 	if err != nil {
 		return 0, fmt.Errorf("failed to generate salt: %w", err)
 	}
@@ -3,12 +3,14 @@ package crypto
 import (
 	"crypto/hmac"
 	"crypto/sha256"
-	"encoding/hex"
+	"encoding/base64"
 	"fmt"
-	"hash"
+	"strconv"
+	"strings"
 )
 
-// HMACSHA256 generates an HMAC-SHA256 signature
+// HMACSHA256 generates a base64url encoded HMAC-SHA256 signature of message
+// with a base64 (standard or URL-safe, padded or not) encoded secret
 func HMACSHA256(secret, message string) (string, error) {
 	if secret == "" {
 		return "", fmt.Errorf("secret cannot be empty")
@@ -17,46 +19,60 @@ func HMACSHA256(secret, message string) (string, error) {
 		return "", fmt.Errorf("message cannot be empty")
 	}
 
-	// Convert secret to bytes
-	secretBytes, err := hex.DecodeString(secret)
+	secretBytes, err := decodeSecret(secret)
 	if err != nil {
-		// If not hex, treat as raw string
-		secretBytes = []byte(secret)
+		return "", err
 	}
 
 	// Create HMAC-SHA256 hash
 	h := hmac.New(sha256.New, secretBytes)
 	h.Write([]byte(message))
 
-	// Return hex encoded signature
-	return hex.EncodeToString(h.Sum(nil)), nil
+	// Return base64url encoded signature
+	return base64.URLEncoding.EncodeToString(h.Sum(nil)), nil
 }
 
-// hmacNew creates a new HMAC hash with the given secret
-func hmacNew(secret string) (hash.Hash, error) {
-	if secret == "" {
-		return nil, fmt.Errorf("secret cannot be empty")
-	}
+// decodeSecret decodes an API secret, which the CLOB issues base64url encoded
+func decodeSecret(secret string) ([]byte, error) {
+	normalized := strings.TrimRight(strings.NewReplacer("+", "-", "/", "_").Replace(secret), "=")
 
-	// Try to decode as hex first
-	secretBytes, err := hex.DecodeString(secret)
+	secretBytes, err := base64.RawURLEncoding.DecodeString(normalized)
 	if err != nil {
-		// If not hex, use raw string
-		secretBytes = []byte(secret)
+		return nil, fmt.Errorf("invalid base64 secret: %w", err)
 	}
-
-	return hmac.New(sha256.New, secretBytes), nil
+	return secretBytes, nil
 }
 
-// SignRequest signs a request with HMAC-SHA256 using the provided secret
+// SignRequest computes the POLY_SIGNATURE of an L2 authenticated request, matching the
+// official clients: the base64url HMAC-SHA256 of timestamp + method + path + body, keyed
+// with the base64url decoded secret. The query string is not signed, so it is stripped
+// from path.
+//
+// Test vectors, cross-checked with Python's hmac module:
+//
+//	secret "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=", timestamp 1000000,
+//	method "test-sign", path "/orders", body `{"hash": "0x123"}`
+//	=> "ZwAdJKvoYRlEKDkNMwd5BuwNNtg93kNaR_oU2HrfVvc="
+//
+//	secret "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=", timestamp 1700000000,
+//	method "GET", path "/data/orders" (or "/data/orders?market=0x1"), no body
+//	=> "NTt0e8XQzUuVTici5whIU-0NfgRXijqf0FarNw-ik2Q="
+//
+//	same secret and timestamp, method "POST", path "/order",
+//	body `{"orderType":"GTC","owner":"key"}`
+//	=> "xG1d5pKw5SJTMG4vDa_4DUi1oqUAwefe0hBBZ6E7Cb8="
 func SignRequest(secret, method, path, body string, timestamp int64) (string, error) {
 	if secret == "" {
 		return "", fmt.Errorf("secret cannot be empty")
 	}
 
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
 	// Create message to sign
-	// Format: method + path + body + timestamp
-	message := fmt.Sprintf("%s%s%s%d", method, path, body, timestamp)
+	// Format: timestamp + method + path + body
+	message := strconv.FormatInt(timestamp, 10) + method + path + body
 
 	return HMACSHA256(secret, message)
 }
@@ -0,0 +1,81 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestSignRequestVectors(t *testing.T) {
+	const secret = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+
+	tests := []struct {
+		name      string
+		secret    string
+		method    string
+		path      string
+		body      string
+		timestamp int64
+		want      string
+	}{
+		{"zero secret", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=", "test-sign", "/orders", `{"hash": "0x123"}`, 1000000, "ZwAdJKvoYRlEKDkNMwd5BuwNNtg93kNaR_oU2HrfVvc="},
+		{"zero secret unpadded", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", "test-sign", "/orders", `{"hash": "0x123"}`, 1000000, "ZwAdJKvoYRlEKDkNMwd5BuwNNtg93kNaR_oU2HrfVvc="},
+		{"get", secret, "GET", "/data/orders", "", 1700000000, "NTt0e8XQzUuVTici5whIU-0NfgRXijqf0FarNw-ik2Q="},
+		{"get with query string", secret, "GET", "/data/orders?market=0x1", "", 1700000000, "NTt0e8XQzUuVTici5whIU-0NfgRXijqf0FarNw-ik2Q="},
+		{"get unpadded secret", strings.TrimRight(secret, "="), "GET", "/data/orders", "", 1700000000, "NTt0e8XQzUuVTici5whIU-0NfgRXijqf0FarNw-ik2Q="},
+		{"post", secret, "POST", "/order", `{"orderType":"GTC","owner":"key"}`, 1700000000, "xG1d5pKw5SJTMG4vDa_4DUi1oqUAwefe0hBBZ6E7Cb8="},
+	}
+
+	for _, tt := range tests {
+		got, err := SignRequest(tt.secret, tt.method, tt.path, tt.body, tt.timestamp)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: signature = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSignRequestSecretEncodings(t *testing.T) {
+	// Bytes whose base64 encodings contain the characters that differ between the alphabets
+	key := []byte{0xfb, 0xef, 0xff, 0xfe, 0x3e, 0x3f, 0x00, 0x10, 0x83, 0xff}
+
+	const message = "1700000000GET/data/orders"
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(message))
+	want := base64.URLEncoding.EncodeToString(h.Sum(nil))
+
+	encodings := []struct {
+		name     string
+		encoding *base64.Encoding
+	}{
+		{"standard", base64.StdEncoding},
+		{"standard unpadded", base64.RawStdEncoding},
+		{"url-safe", base64.URLEncoding},
+		{"url-safe unpadded", base64.RawURLEncoding},
+	}
+
+	for _, e := range encodings {
+		secret := e.encoding.EncodeToString(key)
+		got, err := SignRequest(secret, "GET", "/data/orders", "", 1700000000)
+		if err != nil {
+			t.Errorf("%s secret %s: %v", e.name, secret, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s secret %s: signature = %s, want %s", e.name, secret, got, want)
+		}
+	}
+}
+
+func TestSignRequestInvalidSecret(t *testing.T) {
+	for _, secret := range []string{"", "not base64!", "A"} {
+		if _, err := SignRequest(secret, "GET", "/data/orders", "", 1700000000); err == nil {
+			t.Errorf("SignRequest with secret %q succeeded, want an error", secret)
+		}
+	}
+}
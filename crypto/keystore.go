@@ -0,0 +1,29 @@
+package crypto
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// NewKeystoreSigner creates a signer from go-ethereum V3 keystore JSON encrypted with passphrase
+func NewKeystoreSigner(keyJSON []byte, passphrase string) (*PrivateKeySigner, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+
+	return NewPrivateKeySignerFromKey(key.PrivateKey), nil
+}
+
+// LoadKeystoreSigner creates a signer from a go-ethereum V3 keystore file, e.g. one created
+// by geth account new or clef
+func LoadKeystoreSigner(path, passphrase string) (*PrivateKeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	return NewKeystoreSigner(keyJSON, passphrase)
+}
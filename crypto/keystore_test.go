@@ -0,0 +1,63 @@
+package crypto
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestLoadKeystoreSigner(t *testing.T) {
+	dir := t.TempDir()
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"version":3}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		passphrase string
+		wantErr    string
+	}{
+		{"correct passphrase", account.URL.Path, "passphrase", ""},
+		{"wrong passphrase", account.URL.Path, "wrong", "failed to decrypt keystore"},
+		{"missing file", filepath.Join(dir, "missing.json"), "passphrase", "failed to read keystore"},
+		{"invalid keystore", invalid, "passphrase", "failed to decrypt keystore"},
+	}
+
+	for _, tt := range tests {
+		signer, err := LoadKeystoreSigner(tt.path, tt.passphrase)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if signer.Address() != account.Address {
+			t.Errorf("%s: address = %s, want %s", tt.name, signer.Address().Hex(), account.Address.Hex())
+		}
+	}
+
+	// A wrong passphrase is reported as the keystore's decryption error
+	if _, err := LoadKeystoreSigner(account.URL.Path, "wrong"); !errors.Is(err, keystore.ErrDecrypt) {
+		t.Errorf("error = %v, want keystore.ErrDecrypt", err)
+	}
+}
@@ -0,0 +1,108 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// ZeroAddress is used as the taker for public orders
+const ZeroAddress = "0x0000000000000000000000000000000000000000"
+
+// orderTypes holds the EIP-712 type definitions of the CTF Exchange Order struct
+var orderTypes = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	"Order": {
+		{Name: "salt", Type: "uint256"},
+		{Name: "maker", Type: "address"},
+		{Name: "signer", Type: "address"},
+		{Name: "taker", Type: "address"},
+		{Name: "tokenId", Type: "uint256"},
+		{Name: "makerAmount", Type: "uint256"},
+		{Name: "takerAmount", Type: "uint256"},
+		{Name: "expiration", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "feeRateBps", Type: "uint256"},
+		{Name: "side", Type: "uint8"},
+		{Name: "signatureType", Type: "uint8"},
+	},
+}
+
+// SideIndex returns the on-chain enum index of an order side
+func SideIndex(side types.OrderSide) (int, error) {
+	switch side {
+	case types.BUY:
+		return 0, nil
+	case types.SELL:
+		return 1, nil
+	default:
+		return 0, fmt.Errorf("invalid order side: %q", side)
+	}
+}
+
+// BuildOrderTypedData builds the EIP-712 typed data for a CTF Exchange order
+func BuildOrderTypedData(order *types.Order, chainID int64, exchangeAddress string) (apitypes.TypedData, error) {
+	if order == nil {
+		return apitypes.TypedData{}, fmt.Errorf("order cannot be nil")
+	}
+	if !common.IsHexAddress(exchangeAddress) {
+		return apitypes.TypedData{}, fmt.Errorf("invalid exchange address: %q", exchangeAddress)
+	}
+
+	side, err := SideIndex(order.Side)
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+
+	typedData := apitypes.TypedData{
+		Types:       orderTypes,
+		PrimaryType: "Order",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Polymarket CTF Exchange",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(chainID),
+			VerifyingContract: common.HexToAddress(exchangeAddress).Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"salt":          order.Salt,
+			"maker":         order.Maker,
+			"signer":        order.Signer,
+			"taker":         order.Taker,
+			"tokenId":       order.TokenID,
+			"makerAmount":   order.MakerAmount,
+			"takerAmount":   order.TakerAmount,
+			"expiration":    order.Expiration,
+			"nonce":         order.Nonce,
+			"feeRateBps":    order.FeeRateBps,
+			"side":          fmt.Sprintf("%d", side),
+			"signatureType": fmt.Sprintf("%d", order.SignatureType),
+		},
+	}
+
+	return typedData, nil
+}
+
+// SignOrder signs an order against the given exchange contract and returns the hex signature
+func (s *EIP712Signer) SignOrder(ctx context.Context, order *types.Order, exchangeAddress string) (string, error) {
+	typedData, err := BuildOrderTypedData(order, s.chainID, exchangeAddress)
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := s.SignTypedData(ctx, typedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign order: %w", err)
+	}
+
+	return hexutil.Encode(sig), nil
+}
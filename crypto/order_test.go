@@ -0,0 +1,132 @@
+package crypto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// testOrder returns an order of the testPrivateKey account against the mainnet exchange
+func testOrder() *types.Order {
+	return &types.Order{
+		Salt:          "479249096354",
+		Maker:         "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		Signer:        "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		Taker:         ZeroAddress,
+		TokenID:       "1234",
+		MakerAmount:   "100000000",
+		TakerAmount:   "50000000",
+		Expiration:    "0",
+		Nonce:         "0",
+		FeeRateBps:    "100",
+		Side:          types.BUY,
+		SignatureType: int(types.EOA),
+	}
+}
+
+// word left-pads b to a 32 byte ABI word
+func word(b []byte) []byte {
+	return common.LeftPadBytes(b, 32)
+}
+
+// encodeOrderHash hashes testOrder by hand, independently of apitypes
+func encodeOrderHash(order *types.Order, chainID int64, exchange string) common.Hash {
+	uint256 := func(s string) []byte {
+		n, _ := new(big.Int).SetString(s, 10)
+		return word(n.Bytes())
+	}
+	address := func(s string) []byte {
+		return word(common.HexToAddress(s).Bytes())
+	}
+
+	domainType := crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	domain := crypto.Keccak256(domainType,
+		crypto.Keccak256([]byte("Polymarket CTF Exchange")),
+		crypto.Keccak256([]byte("1")),
+		word(big.NewInt(chainID).Bytes()),
+		address(exchange),
+	)
+
+	side, _ := SideIndex(order.Side)
+	orderType := crypto.Keccak256([]byte("Order(uint256 salt,address maker,address signer,address taker,uint256 tokenId,uint256 makerAmount,uint256 takerAmount,uint256 expiration,uint256 nonce,uint256 feeRateBps,uint8 side,uint8 signatureType)"))
+	message := crypto.Keccak256(orderType,
+		uint256(order.Salt),
+		address(order.Maker),
+		address(order.Signer),
+		address(order.Taker),
+		uint256(order.TokenID),
+		uint256(order.MakerAmount),
+		uint256(order.TakerAmount),
+		uint256(order.Expiration),
+		uint256(order.Nonce),
+		uint256(order.FeeRateBps),
+		word([]byte{byte(side)}),
+		word([]byte{byte(order.SignatureType)}),
+	)
+
+	return common.BytesToHash(crypto.Keccak256([]byte("\x19\x01"), domain, message))
+}
+
+func TestOrderHashVector(t *testing.T) {
+	exchange := types.PolygonMainnet.Exchange
+
+	hash, err := OrderHash(testOrder(), types.PolygonChainID, exchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0x46c2dec73dae47e86b5d589c084e6c54858d8f988db390421c8ec3a361fb78a2"); hash != want {
+		t.Errorf("OrderHash() = %s, want %s", hash.Hex(), want.Hex())
+	}
+	if want := encodeOrderHash(testOrder(), types.PolygonChainID, exchange); hash != want {
+		t.Errorf("OrderHash() = %s, independent encoding %s", hash.Hex(), want.Hex())
+	}
+}
+
+func TestSignOrderRoundTrip(t *testing.T) {
+	const want = "0x6435b08e46470f66939b330ab21e67f599857708cfbcc084d60738369f53b813595926e177af917b73378f6b3c6ee854ff18037a26f2869b98a483089f60bea71b"
+
+	signer, err := NewEIP712Signer(testPrivateKey, types.PolygonChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := testOrder()
+	order.Signature, err = signer.SignOrder(context.Background(), order, types.PolygonMainnet.Exchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Signature != want {
+		t.Errorf("SignOrder() = %s, want %s", order.Signature, want)
+	}
+
+	// The order survives the wire format, which carries the salt as a JSON number
+	data, err := json.Marshal(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"salt":479249096354,`) {
+		t.Errorf("salt not encoded as a number: %s", data)
+	}
+	var posted types.Order
+	if err := json.Unmarshal(data, &posted); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyOrderSignature(&posted, types.PolygonChainID, types.PolygonMainnet.Exchange); err != nil {
+		t.Errorf("VerifyOrderSignature() = %v", err)
+	}
+
+	// Signatures do not carry over to another exchange or changed amounts
+	if err := VerifyOrderSignature(order, types.PolygonChainID, types.PolygonMainnet.NegRiskExchange); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("VerifyOrderSignature() against the neg-risk exchange = %v, want ErrSignatureMismatch", err)
+	}
+	order.TakerAmount = "60000000"
+	if err := VerifyOrderSignature(order, types.PolygonChainID, types.PolygonMainnet.Exchange); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("VerifyOrderSignature() of a changed order = %v, want ErrSignatureMismatch", err)
+	}
+}
@@ -0,0 +1,176 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignHashRequest is the body sent to a remote signing service
+type SignHashRequest struct {
+	Address string `json:"address"`
+	Hash    string `json:"hash"`
+}
+
+// SignHashResponse is the body returned by a remote signing service
+type SignHashResponse struct {
+	Signature string `json:"signature"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RemoteSigner signs hashes through an HTTP signing service such as a KMS proxy.
+// The service receives a POST with a SignHashRequest and answers with a SignHashResponse.
+type RemoteSigner struct {
+	endpoint   string
+	address    common.Address
+	httpClient *http.Client
+	headers    map[string]string
+}
+
+// NewRemoteSigner creates a signer for the key of address held by the service at endpoint
+func NewRemoteSigner(endpoint, address string) (*RemoteSigner, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("signing endpoint cannot be empty")
+	}
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid signer address: %q", address)
+	}
+
+	return &RemoteSigner{
+		endpoint: endpoint,
+		address:  common.HexToAddress(address),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		headers: make(map[string]string),
+	}, nil
+}
+
+// SetHTTPClient sets the HTTP client used to reach the signing service
+func (s *RemoteSigner) SetHTTPClient(httpClient *http.Client) {
+	s.httpClient = httpClient
+}
+
+// SetHeader sets a header sent with every signing request, e.g. an authorization token
+func (s *RemoteSigner) SetHeader(key, value string) {
+	s.headers[key] = value
+}
+
+// Address returns the address of the remote key
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash asks the signing service to sign a hash and checks that the
+// returned signature was produced by the expected address
+func (s *RemoteSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	reqBody, err := json.Marshal(SignHashRequest{
+		Address: s.address.Hex(),
+		Hash:    hash.Hex(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var response SignHashResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("signing service error: %d - %s", resp.StatusCode, string(respBody))
+	}
+	if resp.StatusCode >= 400 || response.Error != "" {
+		return nil, fmt.Errorf("signing service error: %d - %s", resp.StatusCode, response.Error)
+	}
+
+	sig, err := hexutil.Decode(response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+
+	// Services may return V as 27/28; the Signer contract is 0/1
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	recovered, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	if crypto.PubkeyToAddress(*recovered) != s.address {
+		return nil, fmt.Errorf("signature was not produced by %s", s.address.Hex())
+	}
+
+	return sig, nil
+}
+
+// NewSigningHandler returns an http.Handler implementing the remote signing protocol
+// on top of a local Signer. It can stand in for a signing service in tests.
+func NewSigningHandler(signer Signer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(SignHashResponse{Error: "method not allowed"})
+			return
+		}
+
+		var request SignHashRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(SignHashResponse{Error: "invalid request body"})
+			return
+		}
+
+		if !common.IsHexAddress(request.Address) || common.HexToAddress(request.Address) != signer.Address() {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(SignHashResponse{Error: "unknown address"})
+			return
+		}
+
+		hash, err := hexutil.Decode(request.Hash)
+		if err != nil || len(hash) != common.HashLength {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(SignHashResponse{Error: "invalid hash"})
+			return
+		}
+
+		sig, err := signer.SignHash(r.Context(), common.BytesToHash(hash))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(SignHashResponse{Error: err.Error()})
+			return
+		}
+
+		json.NewEncoder(w).Encode(SignHashResponse{Signature: hexutil.Encode(sig)})
+	})
+}
@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testPrivateKey is a well-known development key, never used on mainnet
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+func TestRemoteSignerSignHash(t *testing.T) {
+	local, err := NewPrivateKeySigner(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(NewSigningHandler(local))
+	defer server.Close()
+
+	remote, err := NewRemoteSigner(server.URL, local.Address().Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := common.HexToHash("0x1234")
+	sig, err := remote.SignHash(context.Background(), hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recovered, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crypto.PubkeyToAddress(*recovered) != local.Address() {
+		t.Errorf("signature recovers to %s, want %s", crypto.PubkeyToAddress(*recovered).Hex(), local.Address().Hex())
+	}
+}
+
+func TestRemoteSignerSignHashCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	remote, err := NewRemoteSigner(server.URL, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = remote.SignHash(ctx, common.HexToHash("0x1234"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SignHash error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("SignHash returned after %s, want it to stop with the context", elapsed)
+	}
+}
@@ -0,0 +1,62 @@
+package crypto
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs hashes on behalf of an Ethereum address
+type Signer interface {
+	// Address returns the address of the signing key
+	Address() common.Address
+	// SignHash signs a 32-byte hash and returns a 65-byte [R || S || V] signature.
+	// Implementations that sign remotely must give up when ctx is done.
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// PrivateKeySigner signs with a private key held in process memory
+type PrivateKeySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewPrivateKeySigner creates a signer from a hex encoded private key
+func NewPrivateKeySigner(privateKeyHex string) (*PrivateKeySigner, error) {
+	if privateKeyHex == "" {
+		return nil, fmt.Errorf("private key cannot be empty")
+	}
+
+	// Remove 0x prefix if present
+	privateKeyHex = strings.TrimPrefix(privateKeyHex, "0x")
+
+	// Convert hex to private key
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return NewPrivateKeySignerFromKey(privateKey), nil
+}
+
+// NewPrivateKeySignerFromKey creates a signer from an ECDSA private key
+func NewPrivateKeySignerFromKey(privateKey *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+}
+
+// Address returns the signer's address
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash signs a hash with the private key
+func (s *PrivateKeySigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash.Bytes(), s.privateKey)
+}
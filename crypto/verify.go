@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// ErrSignatureMismatch is returned when a signature was not produced by the expected address
+var ErrSignatureMismatch = errors.New("signature does not match signer")
+
+// RecoverAddress recovers the address that produced a hex encoded signature over hash
+func RecoverAddress(hash common.Hash, sigHex string) (common.Address, error) {
+	sig, err := hexutil.Decode(sigHex)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+
+	if sig[crypto.RecoveryIDOffset] == 27 || sig[crypto.RecoveryIDOffset] == 28 {
+		sig[crypto.RecoveryIDOffset] -= 27 // Transform yellow paper V from 27/28 to 0/1
+	}
+
+	recovered, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover public key: %w", err)
+	}
+
+	return crypto.PubkeyToAddress(*recovered), nil
+}
+
+// VerifySignature checks that sigHex is a signature over hash by address
+func VerifySignature(address, sigHex string, hash common.Hash) error {
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid address: %q", address)
+	}
+
+	recovered, err := RecoverAddress(hash, sigHex)
+	if err != nil {
+		return err
+	}
+
+	if recovered != common.HexToAddress(address) {
+		return fmt.Errorf("%w: expected %s, recovered %s", ErrSignatureMismatch, common.HexToAddress(address).Hex(), recovered.Hex())
+	}
+
+	return nil
+}
+
+// OrderHash computes the EIP-712 hash of an order, which the CLOB uses as the order ID
+func OrderHash(order *types.Order, chainID int64, exchangeAddress string) (common.Hash, error) {
+	typedData, err := BuildOrderTypedData(order, chainID, exchangeAddress)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return HashTypedData(typedData)
+}
+
+// VerifyOrderSignature checks that a signed order was signed by its Signer
+func VerifyOrderSignature(order *types.Order, chainID int64, exchangeAddress string) error {
+	if order == nil {
+		return fmt.Errorf("order cannot be nil")
+	}
+	if order.Signature == "" {
+		return fmt.Errorf("order is not signed")
+	}
+
+	hash, err := OrderHash(order, chainID, exchangeAddress)
+	if err != nil {
+		return err
+	}
+
+	return VerifySignature(order.Signer, order.Signature, hash)
+}
+
+// ClobAuthHash computes the EIP-712 hash of a CLOB authentication message
+func ClobAuthHash(address, timestamp string, nonce uint64, chainID int64) (common.Hash, error) {
+	return HashTypedData(BuildClobAuthTypedData(address, timestamp, nonce, chainID))
+}
+
+// VerifyClobAuthSignature checks that a ClobAuth signature was produced by address
+func VerifyClobAuthSignature(address, timestamp string, nonce uint64, chainID int64, sigHex string) error {
+	hash, err := ClobAuthHash(address, timestamp, nonce, chainID)
+	if err != nil {
+		return err
+	}
+
+	return VerifySignature(address, sigHex, hash)
+}
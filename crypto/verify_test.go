@@ -0,0 +1,217 @@
+package crypto
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lajosdeme/polymarket-go-api/types"
+)
+
+// otherPrivateKey is a second well-known development key, never used on mainnet
+const otherPrivateKey = "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+
+// testAddress is the address of testPrivateKey
+const testAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+// withV returns sigHex with its recovery byte V set to v
+func withV(t *testing.T, sigHex string, v byte) string {
+	t.Helper()
+
+	sig, err := hexutil.Decode(sigHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] = v
+	return hexutil.Encode(sig)
+}
+
+// checkErr reports whether err matches want, an error substring or "" for no error
+func checkErr(t *testing.T, name string, err error, want string) {
+	t.Helper()
+
+	switch {
+	case want == "" && err != nil:
+		t.Errorf("%s: unexpected error: %v", name, err)
+	case want != "" && (err == nil || !strings.Contains(err.Error(), want)):
+		t.Errorf("%s: error = %v, want %q", name, err, want)
+	}
+}
+
+func TestRecoverAddress(t *testing.T) {
+	hash := crypto.Keccak256Hash([]byte("polymarket"))
+
+	signer, err := NewPrivateKeySigner(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := signer.SignHash(context.Background(), hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := hexutil.Encode(raw)
+	v := raw[crypto.RecoveryIDOffset]
+
+	other, err := NewPrivateKeySigner(otherPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRaw, err := other.SignHash(context.Background(), hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		sig     string
+		want    common.Address
+		wantErr string
+	}{
+		{"V 0/1", withV(t, sig, v), signer.Address(), ""},
+		{"V 27/28", withV(t, sig, v+27), signer.Address(), ""},
+		{"other signer", hexutil.Encode(otherRaw), other.Address(), ""},
+		{"invalid V", withV(t, sig, v+29), common.Address{}, "failed to recover public key"},
+		{"malformed hex", "0x" + strings.Repeat("zz", crypto.SignatureLength), common.Address{}, "invalid signature encoding"},
+		{"missing 0x prefix", sig[2:], common.Address{}, "invalid signature encoding"},
+		{"too short", sig[:len(sig)-2], common.Address{}, "invalid signature length: 64"},
+		{"too long", sig + "00", common.Address{}, "invalid signature length: 66"},
+		{"empty", "0x", common.Address{}, "invalid signature length: 0"},
+	}
+
+	for _, tt := range tests {
+		got, err := RecoverAddress(hash, tt.sig)
+		checkErr(t, tt.name, err, tt.wantErr)
+		if got != tt.want {
+			t.Errorf("%s: RecoverAddress() = %s, want %s", tt.name, got.Hex(), tt.want.Hex())
+		}
+	}
+}
+
+func TestOrderHash(t *testing.T) {
+	sell := testOrder()
+	sell.Side = types.SELL
+	invalidSide := testOrder()
+	invalidSide.Side = "HOLD"
+	invalidSalt := testOrder()
+	invalidSalt.Salt = "salt"
+
+	tests := []struct {
+		name     string
+		order    *types.Order
+		chainID  int64
+		exchange string
+		wantErr  string
+	}{
+		{"mainnet exchange", testOrder(), types.PolygonChainID, types.PolygonMainnet.Exchange, ""},
+		{"mainnet neg-risk exchange", testOrder(), types.PolygonChainID, types.PolygonMainnet.NegRiskExchange, ""},
+		{"amoy exchange", testOrder(), types.AmoyChainID, types.AmoyTestnet.Exchange, ""},
+		{"sell order", sell, types.PolygonChainID, types.PolygonMainnet.Exchange, ""},
+		{"nil order", nil, types.PolygonChainID, types.PolygonMainnet.Exchange, "order cannot be nil"},
+		{"invalid exchange", testOrder(), types.PolygonChainID, "0x1234", "invalid exchange address"},
+		{"invalid side", invalidSide, types.PolygonChainID, types.PolygonMainnet.Exchange, "invalid order side"},
+		{"invalid salt", invalidSalt, types.PolygonChainID, types.PolygonMainnet.Exchange, "salt"},
+	}
+
+	for _, tt := range tests {
+		hash, err := OrderHash(tt.order, tt.chainID, tt.exchange)
+		checkErr(t, tt.name, err, tt.wantErr)
+		if err != nil || tt.wantErr != "" {
+			continue
+		}
+		if want := encodeOrderHash(tt.order, tt.chainID, tt.exchange); hash != want {
+			t.Errorf("%s: OrderHash() = %s, independent encoding %s", tt.name, hash.Hex(), want.Hex())
+		}
+	}
+}
+
+func TestVerifyOrderSignature(t *testing.T) {
+	sign := func(privateKey string) string {
+		signer, err := NewEIP712Signer(privateKey, types.PolygonChainID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := signer.SignOrder(context.Background(), testOrder(), types.PolygonMainnet.Exchange)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	sig := sign(testPrivateKey)
+	v, err := hexutil.Decode(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		signature string
+		signer    string
+		wantErr   string
+	}{
+		{"V 27/28", sig, testAddress, ""},
+		{"V 0/1", withV(t, sig, v[crypto.RecoveryIDOffset]-27), testAddress, ""},
+		{"other signer", sign(otherPrivateKey), testAddress, ErrSignatureMismatch.Error()},
+		{"other signer field", sig, "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", ErrSignatureMismatch.Error()},
+		{"invalid signer field", sig, "0x1234", "doesn't match type 'address'"},
+		{"unsigned", "", testAddress, "order is not signed"},
+		{"malformed hex", "0x" + strings.Repeat("zz", crypto.SignatureLength), testAddress, "invalid signature encoding"},
+		{"wrong length", sig[:len(sig)-2], testAddress, "invalid signature length"},
+	}
+
+	for _, tt := range tests {
+		order := testOrder()
+		order.Signer = tt.signer
+		order.Signature = tt.signature
+		checkErr(t, tt.name, VerifyOrderSignature(order, types.PolygonChainID, types.PolygonMainnet.Exchange), tt.wantErr)
+	}
+}
+
+func TestVerifyClobAuthSignature(t *testing.T) {
+	const timestamp = "1700000000"
+	const nonce = 7
+
+	sign := func(privateKey string) string {
+		signer, err := NewEIP712Signer(privateKey, types.PolygonChainID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := signer.SignClobAuth(context.Background(), timestamp, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	sig := sign(testPrivateKey)
+	v, err := hexutil.Decode(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		address   string
+		timestamp string
+		nonce     uint64
+		chainID   int64
+		signature string
+		wantErr   string
+	}{
+		{"V 27/28", testAddress, timestamp, nonce, types.PolygonChainID, sig, ""},
+		{"V 0/1", testAddress, timestamp, nonce, types.PolygonChainID, withV(t, sig, v[crypto.RecoveryIDOffset]-27), ""},
+		{"other signer", testAddress, timestamp, nonce, types.PolygonChainID, sign(otherPrivateKey), ErrSignatureMismatch.Error()},
+		{"other timestamp", testAddress, "1700000001", nonce, types.PolygonChainID, sig, ErrSignatureMismatch.Error()},
+		{"other nonce", testAddress, timestamp, nonce + 1, types.PolygonChainID, sig, ErrSignatureMismatch.Error()},
+		{"other chain", testAddress, timestamp, nonce, types.AmoyChainID, sig, ErrSignatureMismatch.Error()},
+		{"invalid address", "0x1234", timestamp, nonce, types.PolygonChainID, sig, "doesn't match type 'address'"},
+		{"malformed hex", testAddress, timestamp, nonce, types.PolygonChainID, "0x" + strings.Repeat("zz", crypto.SignatureLength), "invalid signature encoding"},
+		{"wrong length", testAddress, timestamp, nonce, types.PolygonChainID, sig[:len(sig)-2], "invalid signature length"},
+	}
+
+	for _, tt := range tests {
+		err := VerifyClobAuthSignature(tt.address, tt.timestamp, tt.nonce, tt.chainID, tt.signature)
+		checkErr(t, tt.name, err, tt.wantErr)
+	}
+}
@@ -184,36 +184,27 @@ func orderManagementExample() error {
 
 	ctx := context.Background()
 
-	// Note: Real order creation requires proper EIP-712 signing of the order
-	// This is just showing the structure - see documentation for complete implementation
-
-	// Example order structure (requires proper signing before submission):
-	// salt, err := crypto.GenerateSalt()
+	// Placing orders additionally requires L1 authentication so the order can be signed:
+	// err = c.SetupL1Auth("0x...", types.EOA, "")
+	//
+	// order, err := api.NewOrderBuilder(c).BuildOrder(ctx, types.OrderArgs{
+	//     TokenID:    "1234567890",
+	//     Price:      0.5,
+	//     Size:       10,
+	//     Side:       types.BUY,
+	//     Expiration: 0,
+	// }, nil)
 	// if err != nil {
-	//     return fmt.Errorf("failed to generate salt: %w", err)
+	//     return fmt.Errorf("failed to build order: %w", err)
 	// }
 	//
 	// postOrder := types.PostOrder{
-	//     Order: types.Order{
-	//         Salt:          fmt.Sprintf("%d", salt),
-	//         Maker:         c.GetAuthManager().GetAddress(),
-	//         Signer:        c.GetAuthManager().GetAddress(),
-	//         Taker:         "0x0000000000000000000000000000000000000",
-	//         TokenID:       "1234567890",
-	//         MakerAmount:   "1000000", // 1 USDC in wei (6 decimals)
-	//         TakerAmount:   "1000000", // 1 token
-	//         Expiration:    fmt.Sprintf("%d", time.Now().Add(24*time.Hour).Unix()),
-	//         Nonce:         "0",
-	//         FeeRateBps:    "0",
-	//         Side:          types.BUY,
-	//         SignatureType: int(types.EOA),
-	//         Signature:     "0x...", // Proper signature needed here
-	//     },
+	//     Order:     *order,
 	//     OrderType: types.GTC,
 	//     Owner:     c.GetAuthManager().GetAPICredentials().APIKey,
 	// }
 
-	// Place order (commented out as it requires proper signing)
+	// Place order (commented out as it requires a funded wallet)
 	/*
 		response, err := ordersAPI.PlaceOrder(ctx, postOrder)
 		if err != nil {
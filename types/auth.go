@@ -1,5 +1,11 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // SignatureType represents the type of signature used for authentication
 type SignatureType int
 
@@ -26,6 +32,20 @@ func (st SignatureType) String() string {
 	}
 }
 
+// ParseSignatureType parses a signature type given by name (e.g. "POLY_PROXY") or number
+func ParseSignatureType(s string) (SignatureType, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "", "EOA", "0":
+		return EOA, nil
+	case "POLY_PROXY", "1":
+		return POLY_PROXY, nil
+	case "GNOSIS_SAFE", "2":
+		return GNOSIS_SAFE, nil
+	default:
+		return EOA, fmt.Errorf("unknown signature type: %s", s)
+	}
+}
+
 // APICredentials represents L2 authentication credentials
 type APICredentials struct {
 	APIKey     string `json:"apiKey"`
@@ -33,6 +53,46 @@ type APICredentials struct {
 	Passphrase string `json:"passphrase"`
 }
 
+// APIKeysResponse lists the API keys of an account
+type APIKeysResponse struct {
+	APIKeys []string `json:"apiKeys"`
+}
+
+// UnmarshalJSON accepts keys listed as strings or as credential objects
+func (r *APIKeysResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		APIKeys []json.RawMessage `json:"apiKeys"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	r.APIKeys = make([]string, 0, len(raw.APIKeys))
+	for _, item := range raw.APIKeys {
+		var key string
+		if err := json.Unmarshal(item, &key); err != nil {
+			var creds APICredentials
+			if err := json.Unmarshal(item, &creds); err != nil {
+				return err
+			}
+			key = creds.APIKey
+		}
+		r.APIKeys = append(r.APIKeys, key)
+	}
+	return nil
+}
+
+// ReadonlyAPIKeyResponse is a created read-only API key, which can query orders and trades
+// but not place or cancel orders
+type ReadonlyAPIKeyResponse struct {
+	APIKey string `json:"apiKey"`
+}
+
+// DeleteReadonlyAPIKeyRequest represents a request to delete a read-only API key
+type DeleteReadonlyAPIKeyRequest struct {
+	Key string `json:"key"`
+}
+
 // EIP712Domain represents the EIP-712 domain for CLOB authentication
 type EIP712Domain struct {
 	Name    string `json:"name"`
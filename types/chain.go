@@ -0,0 +1,60 @@
+package types
+
+import "fmt"
+
+const (
+	// PolygonChainID - Polygon mainnet
+	PolygonChainID int64 = 137
+	// AmoyChainID - Polygon Amoy testnet
+	AmoyChainID int64 = 80002
+)
+
+// ChainConfig represents the chain and contract addresses used for signing
+type ChainConfig struct {
+	ChainID           int64  `json:"chainId"`
+	Exchange          string `json:"exchange"`
+	NegRiskExchange   string `json:"negRiskExchange"`
+	NegRiskAdapter    string `json:"negRiskAdapter"`
+	Collateral        string `json:"collateral"`
+	ConditionalTokens string `json:"conditionalTokens"`
+}
+
+// PolygonMainnet is the contract configuration of Polygon mainnet
+var PolygonMainnet = ChainConfig{
+	ChainID:           PolygonChainID,
+	Exchange:          "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E",
+	NegRiskExchange:   "0xC5d563A36AE78145C45a50134d48A1215220f80a",
+	NegRiskAdapter:    "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296",
+	Collateral:        "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174",
+	ConditionalTokens: "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045",
+}
+
+// AmoyTestnet is the contract configuration of the Polygon Amoy testnet, taken from the
+// official clients. They publish no neg-risk adapter for Amoy, so NegRiskAdapter is empty.
+var AmoyTestnet = ChainConfig{
+	ChainID:           AmoyChainID,
+	Exchange:          "0xdFE02Eb6733538f8Ea35D585af8DE5958AD99E40",
+	NegRiskExchange:   "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296",
+	Collateral:        "0x9c4e1703476e875070ee25b56a58b008cfb8fa78",
+	ConditionalTokens: "0x69308FB512518e39F9b16112fA8d994F4e2Bf8bB",
+}
+
+// GetChainConfig returns the known contract configuration of a chain
+func GetChainConfig(chainID int64) (ChainConfig, error) {
+	switch chainID {
+	case PolygonChainID:
+		return PolygonMainnet, nil
+	case AmoyChainID:
+		return AmoyTestnet, nil
+	default:
+		return ChainConfig{}, fmt.Errorf("unsupported chain ID: %d", chainID)
+	}
+}
+
+// ExchangeAddress returns the exchange contract that orders of a market must be signed against
+func (c ChainConfig) ExchangeAddress(negRisk bool) string {
+	if negRisk {
+		return c.NegRiskExchange
+	}
+	return c.Exchange
+}
@@ -0,0 +1,307 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorCode represents API error codes
+type ErrorCode string
+
+const (
+	// Invalid order errors
+	ErrInvalidOrderMinTickSize ErrorCode = "INVALID_ORDER_MIN_TICK_SIZE"
+	ErrInvalidOrderMinSize     ErrorCode = "INVALID_ORDER_MIN_SIZE"
+	ErrInvalidOrderDuplicated  ErrorCode = "INVALID_ORDER_DUPLICATED"
+	ErrInvalidOrderBalance     ErrorCode = "INVALID_ORDER_NOT_ENOUGH_BALANCE"
+	ErrInvalidOrderExpiration  ErrorCode = "INVALID_ORDER_EXPIRATION"
+	ErrInvalidOrderError       ErrorCode = "INVALID_ORDER_ERROR"
+
+	// Execution errors
+	ErrExecutionError     ErrorCode = "EXECUTION_ERROR"
+	ErrOrderDelayed       ErrorCode = "ORDER_DELAYED"
+	ErrDelayingOrderError ErrorCode = "DELAYING_ORDER_ERROR"
+	ErrFOKOrderNotFilled  ErrorCode = "FOK_ORDER_NOT_FILLED_ERROR"
+	ErrMarketNotReady     ErrorCode = "MARKET_NOT_READY"
+
+	// Authentication errors
+	ErrInvalidSignature     ErrorCode = "INVALID_SIGNATURE"
+	ErrNonceAlreadyUsed     ErrorCode = "NONCE_ALREADY_USED"
+	ErrInvalidFunderAddress ErrorCode = "INVALID_FUNDER_ADDRESS"
+
+	// General errors
+	ErrInternalError ErrorCode = "INTERNAL_ERROR"
+	ErrRateLimited   ErrorCode = "RATE_LIMITED"
+	ErrUnauthorized  ErrorCode = "UNAUTHORIZED"
+	ErrForbidden     ErrorCode = "FORBIDDEN"
+	ErrNotFound      ErrorCode = "NOT_FOUND"
+	ErrBadRequest    ErrorCode = "BAD_REQUEST"
+
+	// Client-side errors
+	ErrCircuitOpen ErrorCode = "CIRCUIT_OPEN"
+)
+
+// ClobError represents a CLOB or Gamma API error
+type ClobError struct {
+	Code       ErrorCode     `json:"code"`
+	Message    string        `json:"message"`
+	Success    bool          `json:"success"`
+	StatusCode int           `json:"status_code,omitempty"`
+	Details    string        `json:"details,omitempty"`
+	Method     string        `json:"method,omitempty"`
+	Path       string        `json:"path,omitempty"`
+	RetryAfter time.Duration `json:"retry_after,omitempty"`
+}
+
+// Error implements the error interface
+func (e *ClobError) Error() string {
+	prefix := fmt.Sprintf("CLOB API Error [%s]", e.Code)
+	if e.Method != "" || e.Path != "" {
+		prefix = fmt.Sprintf("CLOB API Error [%s] %s %s", e.Code, e.Method, e.Path)
+	}
+	if e.Details != "" {
+		return fmt.Sprintf("%s: %s - %s", prefix, e.Message, e.Details)
+	}
+	return fmt.Sprintf("%s: %s", prefix, e.Message)
+}
+
+// IsRetryable returns true if the error is retryable
+func (e *ClobError) IsRetryable() bool {
+	switch e.Code {
+	case ErrInternalError, ErrRateLimited, ErrOrderDelayed, ErrExecutionError:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsAuthenticationError returns true if the error is authentication related
+func (e *ClobError) IsAuthenticationError() bool {
+	switch e.Code {
+	case ErrInvalidSignature, ErrNonceAlreadyUsed, ErrInvalidFunderAddress, ErrUnauthorized, ErrForbidden:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsOrderValidationError returns true if the error is order validation related
+func (e *ClobError) IsOrderValidationError() bool {
+	switch e.Code {
+	case ErrInvalidOrderMinTickSize, ErrInvalidOrderMinSize, ErrInvalidOrderDuplicated,
+		ErrInvalidOrderBalance, ErrInvalidOrderExpiration, ErrInvalidOrderError:
+		return true
+	default:
+		return false
+	}
+}
+
+// errorMessagePatterns maps server error messages to error codes
+var errorMessagePatterns = []struct {
+	pattern string
+	code    ErrorCode
+	message string
+}{
+	{"breaks minimum tick size", ErrInvalidOrderMinTickSize, "Order price breaks minimum tick size rules"},
+	{"lower than the minimum", ErrInvalidOrderMinSize, "Order size below minimum threshold"},
+	{"Duplicated", ErrInvalidOrderDuplicated, "Duplicate order already exists"},
+	{"not enough balance", ErrInvalidOrderBalance, "Insufficient balance or allowance for order"},
+	{"invalid expiration", ErrInvalidOrderExpiration, "Order expiration time is in the past"},
+	{"before now", ErrInvalidOrderExpiration, "Order expiration time is in the past"},
+	{"could not insert order", ErrInvalidOrderError, "System error while inserting order"},
+	{"could not run the execution", ErrExecutionError, "System error while executing trade"},
+	{"error delaying the order", ErrDelayingOrderError, "System error while delaying order"},
+	{"order match delayed", ErrOrderDelayed, "Order placement delayed"},
+	{"couldn't be fully filled", ErrFOKOrderNotFilled, "FOK order could not be fully filled"},
+	{"not yet ready", ErrMarketNotReady, "Market is not ready to process new orders"},
+	{"Invalid Funder Address", ErrInvalidFunderAddress, "Invalid funder address"},
+}
+
+// NewClobError creates a new ClobError from HTTP status and response body
+func NewClobError(statusCode int, responseBody []byte) *ClobError {
+	bodyStr := string(responseBody)
+
+	// Try to parse known error codes
+	for _, errCode := range []ErrorCode{
+		ErrInvalidOrderMinTickSize, ErrInvalidOrderMinSize, ErrInvalidOrderDuplicated,
+		ErrInvalidOrderBalance, ErrInvalidOrderExpiration, ErrInvalidOrderError,
+		ErrExecutionError, ErrOrderDelayed, ErrDelayingOrderError,
+		ErrFOKOrderNotFilled, ErrMarketNotReady, ErrInvalidSignature,
+		ErrNonceAlreadyUsed, ErrInvalidFunderAddress,
+	} {
+		if strings.Contains(bodyStr, string(errCode)) {
+			return &ClobError{
+				Code:       errCode,
+				Message:    extractErrorMessage(bodyStr),
+				Success:    false,
+				StatusCode: statusCode,
+				Details:    bodyStr,
+			}
+		}
+	}
+
+	// Try to match known error messages
+	for _, p := range errorMessagePatterns {
+		if strings.Contains(bodyStr, p.pattern) {
+			return &ClobError{
+				Code:       p.code,
+				Message:    p.message,
+				Success:    false,
+				StatusCode: statusCode,
+				Details:    bodyStr,
+			}
+		}
+	}
+
+	// Default error based on HTTP status
+	var code ErrorCode
+	var message string
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		code = ErrRateLimited
+		message = "Rate limit exceeded"
+	case statusCode >= 400 && statusCode < 500:
+		code = ErrBadRequest
+		if statusCode == 401 {
+			code = ErrUnauthorized
+		}
+		if statusCode == 403 {
+			code = ErrForbidden
+		}
+		if statusCode == 404 {
+			code = ErrNotFound
+		}
+		message = fmt.Sprintf("Client error: %d", statusCode)
+	case statusCode >= 500:
+		code = ErrInternalError
+		message = fmt.Sprintf("Server error: %d", statusCode)
+	default:
+		code = ErrInternalError
+		message = fmt.Sprintf("HTTP error: %d", statusCode)
+	}
+
+	if serverMessage := extractServerMessage(bodyStr); serverMessage != "" {
+		message = fmt.Sprintf("%s: %s", message, serverMessage)
+	}
+
+	return &ClobError{
+		Code:       code,
+		Message:    message,
+		Success:    false,
+		StatusCode: statusCode,
+		Details:    bodyStr,
+	}
+}
+
+// NewHTTPError creates a ClobError for a failed HTTP response, including the
+// request method and path and the Retry-After delay requested by the server
+func NewHTTPError(method, path string, resp *http.Response, responseBody []byte) *ClobError {
+	clobErr := NewClobError(resp.StatusCode, responseBody)
+	clobErr.Method = method
+	clobErr.Path = path
+	clobErr.RetryAfter = ParseRetryAfter(resp.Header.Get("Retry-After"))
+	return clobErr
+}
+
+// NewOrderResponseError maps an unsuccessful order placement response to a ClobError.
+// It returns nil when the order was placed successfully.
+func NewOrderResponseError(response *OrderResponse) *ClobError {
+	if response == nil || response.Success {
+		return nil
+	}
+
+	clobErr := NewClobError(0, []byte(response.ErrorMsg))
+	switch {
+	case response.ErrorMsg == "":
+		clobErr.Code = ErrInvalidOrderError
+		clobErr.Message = "Order placement failed"
+	case clobErr.Code == ErrInternalError:
+		// Unknown messages reject the order; they are not retryable server failures
+		clobErr.Code = ErrInvalidOrderError
+		clobErr.Message = response.ErrorMsg
+	}
+	return clobErr
+}
+
+// ParseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func ParseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+// extractServerMessage extracts the error message of a JSON error body
+func extractServerMessage(bodyStr string) string {
+	var body struct {
+		Error    string `json:"error"`
+		ErrorMsg string `json:"errorMsg"`
+		Message  string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(bodyStr), &body); err != nil {
+		return ""
+	}
+
+	switch {
+	case body.Error != "":
+		return body.Error
+	case body.ErrorMsg != "":
+		return body.ErrorMsg
+	default:
+		return body.Message
+	}
+}
+
+// extractErrorMessage attempts to extract a human-readable error message from response body
+func extractErrorMessage(bodyStr string) string {
+	// Look for common error message patterns
+	for _, p := range errorMessagePatterns {
+		if strings.Contains(bodyStr, p.pattern) {
+			return p.message
+		}
+	}
+	if strings.Contains(bodyStr, "INVALID_SIGNATURE") {
+		return "Invalid wallet signature"
+	}
+	if strings.Contains(bodyStr, "NONCE_ALREADY_USED") {
+		return "Nonce has already been used"
+	}
+
+	// Return the server message or a generic message if no specific pattern found
+	if serverMessage := extractServerMessage(bodyStr); serverMessage != "" {
+		return serverMessage
+	}
+	return "API error occurred"
+}
+
+// IsClobError checks if an error is or wraps a ClobError
+func IsClobError(err error) bool {
+	var clobErr *ClobError
+	return errors.As(err, &clobErr)
+}
+
+// AsClobError returns the ClobError wrapped by err, if any
+func AsClobError(err error) (*ClobError, bool) {
+	var clobErr *ClobError
+	if errors.As(err, &clobErr) {
+		return clobErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,34 @@
+package types
+
+import "testing"
+
+func TestNewOrderResponseError(t *testing.T) {
+	tests := []struct {
+		name      string
+		errorMsg  string
+		code      ErrorCode
+		message   string
+		retryable bool
+	}{
+		{"known message", "not enough balance / allowance", ErrInvalidOrderBalance, "Insufficient balance or allowance for order", false},
+		{"known code", "INVALID_ORDER_MIN_TICK_SIZE", ErrInvalidOrderMinTickSize, "API error occurred", false},
+		{"delayed", "order match delayed due to market conditions", ErrOrderDelayed, "Order placement delayed", true},
+		{"unknown message", "market is closed", ErrInvalidOrderError, "market is closed", false},
+		{"empty message", "", ErrInvalidOrderError, "Order placement failed", false},
+	}
+
+	for _, tt := range tests {
+		clobErr := NewOrderResponseError(&OrderResponse{Success: false, ErrorMsg: tt.errorMsg})
+		if clobErr == nil {
+			t.Errorf("%s: no error", tt.name)
+			continue
+		}
+		if clobErr.Code != tt.code || clobErr.Message != tt.message || clobErr.IsRetryable() != tt.retryable {
+			t.Errorf("%s: got %s %q retryable %v, want %s %q retryable %v", tt.name, clobErr.Code, clobErr.Message, clobErr.IsRetryable(), tt.code, tt.message, tt.retryable)
+		}
+	}
+
+	if clobErr := NewOrderResponseError(&OrderResponse{Success: true}); clobErr != nil {
+		t.Errorf("successful response error = %v", clobErr)
+	}
+}
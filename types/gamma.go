@@ -388,6 +388,7 @@ type GammaMarket struct {
 	ShowGmpSeries                *bool           `json:"showGmpSeries"`
 	ShowGmpOutcome               *bool           `json:"showGmpOutcome"`
 	ManualActivation             *bool           `json:"manualActivation"`
+	NegRisk                      *bool           `json:"negRisk"`
 	NegRiskOther                 *bool           `json:"negRiskOther"`
 	GameID                       *string         `json:"gameId"`
 	GroupItemRange               *string         `json:"groupItemRange"`
@@ -6,6 +6,20 @@ type PriceLevel struct {
 	Size  string `json:"size"`
 }
 
+// TickSize represents the minimum price increment of a market
+type TickSize string
+
+const (
+	// TickSize01 - Prices in increments of 0.1
+	TickSize01 TickSize = "0.1"
+	// TickSize001 - Prices in increments of 0.01
+	TickSize001 TickSize = "0.01"
+	// TickSize0001 - Prices in increments of 0.001
+	TickSize0001 TickSize = "0.001"
+	// TickSize00001 - Prices in increments of 0.0001
+	TickSize00001 TickSize = "0.0001"
+)
+
 // Orderbook represents an orderbook for a token
 type Orderbook struct {
 	Market       string       `json:"market"`
@@ -1,5 +1,7 @@
 package types
 
+import "encoding/json"
+
 // OrderSide represents the side of an order (BUY or SELL)
 type OrderSide string
 
@@ -55,6 +57,67 @@ type Order struct {
 	Signature     string    `json:"signature"`
 }
 
+// MarshalJSON encodes the salt as a JSON number, as the CLOB expects
+func (o Order) MarshalJSON() ([]byte, error) {
+	type order Order
+	return json.Marshal(struct {
+		Salt json.Number `json:"salt"`
+		order
+	}{
+		Salt:  json.Number(o.Salt),
+		order: order(o),
+	})
+}
+
+// UnmarshalJSON accepts the salt as a JSON number or string
+func (o *Order) UnmarshalJSON(data []byte) error {
+	type order Order
+	raw := struct {
+		Salt json.Number `json:"salt"`
+		*order
+	}{
+		order: (*order)(o),
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	o.Salt = raw.Salt.String()
+	return nil
+}
+
+// OrderArgs represents the user-facing parameters of a limit order
+type OrderArgs struct {
+	TokenID    string    `json:"tokenId"`
+	Price      float64   `json:"price"`
+	Size       float64   `json:"size"`
+	Side       OrderSide `json:"side"`
+	FeeRateBps int       `json:"feeRateBps"`
+	Nonce      uint64    `json:"nonce"`
+	Expiration int64     `json:"expiration"`
+	Taker      string    `json:"taker,omitempty"`
+}
+
+// MarketOrderArgs represents the user-facing parameters of a market order.
+// Amount is in USDC for BUY orders and in shares for SELL orders.
+type MarketOrderArgs struct {
+	TokenID    string    `json:"tokenId"`
+	Side       OrderSide `json:"side"`
+	Amount     float64   `json:"amount"`
+	OrderType  OrderType `json:"orderType"`
+	FeeRateBps int       `json:"feeRateBps"`
+	Nonce      uint64    `json:"nonce"`
+	Taker      string    `json:"taker,omitempty"`
+}
+
+// CreateOrderOptions represents market parameters used when creating an order.
+// Nil fields are looked up from the CLOB.
+type CreateOrderOptions struct {
+	TickSize     *TickSize `json:"tickSize,omitempty"`
+	MinOrderSize *float64  `json:"minOrderSize,omitempty"`
+	NegRisk      *bool     `json:"negRisk,omitempty"`
+}
+
 // PostOrder represents an order with additional metadata for posting
 type PostOrder struct {
 	Order     Order     `json:"order"`
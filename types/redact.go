@@ -0,0 +1,32 @@
+package types
+
+import "log/slog"
+
+// Redacted replaces secret values in logs
+const Redacted = "[REDACTED]"
+
+// MaskAPIKey keeps the first characters of an API key, so keys can be told apart in logs
+func MaskAPIKey(apiKey string) string {
+	if len(apiKey) <= 8 {
+		return Redacted
+	}
+	return apiKey[:8] + "..."
+}
+
+// LogValue implements slog.LogValuer, redacting the secret and passphrase
+func (c APICredentials) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("apiKey", MaskAPIKey(c.APIKey)),
+		slog.String("secret", Redacted),
+		slog.String("passphrase", Redacted),
+	)
+}
+
+// LogValue implements slog.LogValuer, redacting the secret and passphrase
+func (a WebSocketAuth) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("apiKey", MaskAPIKey(a.APIKey)),
+		slog.String("secret", Redacted),
+		slog.String("passphrase", Redacted),
+	)
+}